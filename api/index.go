@@ -4,6 +4,7 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"strings"
 
 	"github.com/gorilla/mux"
 	"github.com/yuxxeun/jakal/internal/routes"
@@ -40,18 +41,53 @@ func main() {
 					"GET /api/v1/date/{date}": "Konversi tanggal tertentu (format: YYYY-MM-DD)",
 					"GET /api/v1/range/{start}/{end}": "Range tanggal (maksimal 1 tahun)",
 					"GET /api/v1/year/{year}": "Data lengkap untuk tahun tertentu",
-					"GET /api/v1/month/{year}/{month}": "Data lengkap untuk bulan tertentu"
+					"GET /api/v1/month/{year}/{month}": "Data lengkap untuk bulan tertentu",
+					"GET /api/v1/javanese-year/{year}": "Daftar bulan dalam tahun Jawa beserta awal Masehinya",
+					"GET /api/v1/from-javanese/{year}/{month}/{day}": "Konversi tanggal Jawa ke Masehi (bulan berupa angka atau nama)"
 				},
 				"weton": {
-					"GET /api/v1/weton/{date}": "Weton untuk tanggal tertentu",
+					"GET /api/v1/weton/{date}": "Weton untuk tanggal tertentu (opsional ?time=HH:MM dengan ?city= atau ?lat=&lon=, hari berganti saat maghrib)",
 					"GET /api/v1/neptu/{date}": "Neptu untuk tanggal tertentu",
 					"GET /api/v1/compatibility/{date1}/{date2}": "Kecocokan weton dua tanggal",
-					"GET /api/v1/good-days/{birth_date}/{target_year}": "Hari baik berdasarkan weton lahir",
-					"GET /api/v1/wetons": "Daftar semua kemungkinan weton (35 kombinasi)"
+					"GET /api/v1/good-days/{birth_date}/{target_year}": "Hari baik berdasarkan weton lahir (opsional ?purpose=)",
+					"GET /api/v1/good-days/{purpose}/{birth_date}/{target_year}": "Hari baik untuk nikah, pindah-rumah, buka-usaha, tanam, khitan atau bepergian",
+					"GET /api/v1/wedding-dates/{bride_birth}/{groom_birth}/{year}": "Peringkat tanggal pernikahan berdasarkan weton kedua calon (opsional ?exclude_months=sela,besar)",
+					"GET /api/v1/slametan/{date_of_death}": "Tanggal slametan geblag sampai nyewu (opsional ?time=HH:MM&city=)",
+					"GET /api/v1/bad-days/{year}": "Dina ala (taliwangke, samparwangke, dina sangar, naas sasi) dalam setahun",
+					"GET /api/v1/bad-days/{birth_date}/{year}": "Dina ala termasuk naas pribadi dari weton lahir",
+					"GET /api/v1/naga/{date}": "Arah naga dina, naga sasi dan naga tahun yang harus dihindari",
+					"GET /api/v1/hijri/{date}": "Tanggal Hijriah tabular dan hisab untuk tanggal tertentu",
+					"GET /api/v1/holidays/{year}": "Hari besar Jawa, libur nasional dan cuti bersama dalam setahun (opsional ?type=jawa|nasional|cuti_bersama)",
+					"GET /api/v1/holidays/{year}/{month}": "Hari libur dalam bulan tertentu",
+					"GET /api/v1/wetons": "Daftar semua kemungkinan weton (35 kombinasi)",
+					"GET /api/v1/analysis/{date}": "Analisis watak dan primbon berdasarkan tanggal lahir",
+					"GET /api/v1/analysis/weton/{weton}": "Analisis watak dan primbon untuk weton tertentu"
 				},
 				"filter": {
 					"GET /api/v1/weton/{weton}/{year}": "Filter weton dalam tahun (support strip: selasa-legi)",
-					"GET /api/v1/weton/{weton}/{year}/{month}": "Filter weton dalam bulan tertentu"
+					"GET /api/v1/weton/{weton}/{year}/{month}": "Filter weton dalam bulan tertentu",
+					"GET /api/v1/weton/{weton}/range/{start}/{end}": "Semua tanggal berweton tertentu dalam rentang hingga 200 tahun",
+					"GET /api/v1/wuku/{wuku}/{year}": "Filter wuku dalam tahun (support strip: julung-wangi)",
+					"GET /api/v1/wuku/{wuku}/{year}/{month}": "Filter wuku dalam bulan tertentu",
+					"GET /api/v1/wewaran/{cycle}/{value}/{year}": "Filter siklus hari (dwiwara ... dasawara, paringkelan, padewan, padangon)",
+					"GET /api/v1/wewaran/{cycle}/{value}/{year}/{month}": "Filter siklus hari dalam bulan tertentu"
+				},
+				"ical": {
+					"GET /api/v1/ical/weton/{birth_date}.ics": "Feed langganan wetonan, acara berulang tiap 35 hari sejak tanggal lahir",
+					"GET /api/v1/ical/slametan/{date_of_death}.ics": "Feed tanggal slametan geblag sampai nyewu",
+					"GET /api/v1/ical/holidays.ics": "Feed hari libur tahun ini dan tahun depan (opsional ?type=)",
+					"GET /api/v1/ical/holidays/{year}.ics": "Feed hari libur untuk tahun tertentu"
+				},
+				"caldav": {
+					"PROPFIND /caldav/": "Server CalDAV read-only dengan kalender holidays dan pasaran (ditemukan juga lewat /.well-known/caldav)",
+					"PROPFIND /caldav/{birth_date}/": "Home CalDAV per pengguna, menambahkan kalender wetonan untuk tanggal lahir",
+					"REPORT /caldav/{birth_date}/{calendar}/": "calendar-query dengan time-range (maksimal 3660 hari; lebih dari itu ditolak 403 dengan precondition DAV:number-of-matches-within-limits, time-range yang hanya berisi start atau end dibaca 366 hari, bagian di luar tahun 1 - 9999 dipotong) atau calendar-multiget. PROPFIND propname mengembalikan nama properti saja"
+				},
+				"mangsa": {
+					"GET /api/v1/mangsa/{year}": "Batas 12 Pranata Mangsa dalam tahun Masehi"
+				},
+				"kurup": {
+					"GET /api/v1/kurup/{kurup1}/{kurup2}/{start}/{end}": "Tanggal yang berbeda antara dua petungan kurup (maksimal 1 tahun)"
 				},
 				"statistics": {
 					"GET /api/v1/statistics/{start}/{end}": "Statistik weton dalam periode tertentu"
@@ -64,18 +100,47 @@ func main() {
 			"examples": {
 				"today": "/api/v1/today",
 				"specific_date": "/api/v1/date/2025-07-29",
+				"month_text": "/api/v1/month/2025/8?format=text",
+				"javanese_year": "/api/v1/javanese-year/1960",
+				"from_javanese": "/api/v1/from-javanese/1960/sura/1",
+				"pranata_mangsa": "/api/v1/mangsa/2025",
+				"aboge_date": "/api/v1/date/2025-07-29?kurup=aboge",
+				"today_wita": "/api/v1/today?tz=WITA",
+				"historical_date": "/api/v1/date/1600-02-29",
+				"kurup_compare": "/api/v1/kurup/asapon/aboge/2025-06-01/2025-07-31",
 				"weton": "/api/v1/weton/1990-05-15",
+				"weton_after_sunset": "/api/v1/weton/1990-05-17?time=19:00&city=yogyakarta",
 				"neptu": "/api/v1/neptu/1990-05-15",
 				"compatibility": "/api/v1/compatibility/1990-05-15/1992-08-20",
 				"good_days": "/api/v1/good-days/1990-05-15/2025",
+				"good_days_nikah": "/api/v1/good-days/nikah/1990-05-15/2025",
+				"wedding_dates": "/api/v1/wedding-dates/1995-03-12/1993-11-02/2026",
+				"slametan": "/api/v1/slametan/2025-01-10?time=19:30&city=solo",
+				"bad_days": "/api/v1/bad-days/1990-05-15/2025",
+				"naga": "/api/v1/naga/2025-07-29",
+				"hijri": "/api/v1/hijri/2025-03-01?hijri_adjust=-1",
 				"all_wetons": "/api/v1/wetons",
+				"analysis": "/api/v1/analysis/1990-05-15",
+				"analysis_weton": "/api/v1/analysis/weton/selasa-pon",
 				"filter_weton_year": "/api/v1/weton/selasa-legi/2025",
 				"filter_weton_month": "/api/v1/weton/jumat-kliwon/2025/7",
+				"filter_weton_range": "/api/v1/weton/jumat-kliwon/range/1900-01-01/2100-12-31",
+				"filter_wuku": "/api/v1/wuku/galungan/2025",
+				"filter_wewaran": "/api/v1/wewaran/paringkelan/tungle/2025/7",
 				"statistics": "/api/v1/statistics/2025-01-01/2025-12-31"
 			},
 			"notes": {
 				"weton_format": "Sekarang mendukung strip (-) sebagai pengganti spasi. Contoh: 'selasa-legi' atau 'Selasa%20Legi'",
-				"case_insensitive": "Format weton tidak case sensitive: 'selasa-legi' = 'Selasa-Legi' = 'SELASA-LEGI'"
+				"case_insensitive": "Format weton tidak case sensitive: 'selasa-legi' = 'Selasa-Legi' = 'SELASA-LEGI'",
+				"kurup": "Semua endpoint tanggal menerima ?kurup=asapon|aboge|anenge. Tanpa opsi ini, kurup mengikuti urutan sejarah",
+				"timezone": "Hari ini dihitung di zona Asia/Jakarta. Gunakan ?tz=WIB|WITA|WIT|<nama IANA> atau header X-Timezone; zona yang dipakai dikembalikan di field timezone dan header X-Timezone",
+				"calendar": "Tahun 1 - 9999 didukung. Tanggal sebelum 15 Oktober 1582 dibaca sebagai tanggal Julian; gunakan ?calendar=gregorian|julian untuk memaksa satu sistem. Field gregorian_date selalu Gregorian proleptik, julian_date muncul untuk tanggal Julian, dan saka_year untuk tanggal sebelum 8 Juli 1633. Sebelum tanggal itu kalender Jawa Sultan Agung belum berlaku: field tanggal, bulan, tahun, windu dan kurup Jawa beserta penanda turunannya dikosongkan, dan field note menjelaskan alasannya",
+				"hijri": "Field hijri memakai perhitungan tabular (urfi). Gunakan ?hijri=hisab untuk kriteria ijtimak sebelum maghrib di Yogyakarta, atau ?hijri_adjust=-3..3 untuk menggeser hasil tabular",
+				"holidays": "Hari besar Jawa (1 Sura, Grebeg Mulud, Selikuran, Grebeg Pasa, Grebeg Besar, Rebo Wekasan) dihitung dari tanggal Jawa sesuai kurup. Libur nasional dan cuti bersama dibaca dari data SKB per tahun; versi data dikembalikan di field national_data. Setiap tanggal di /date, /month dan /year memuat field holidays bila jatuh pada hari libur",
+				"ical": "Endpoint /month, /year, filter weton dan good-days mengembalikan iCalendar (RFC 5545) dengan ?format=ics atau header Accept: text/calendar. URL /api/v1/ical/... dapat dilanggan langsung dari aplikasi kalender",
+				"format": "Semua endpoint /api/v1 mendukung JSON (bawaan), CSV, XML, MessagePack dan teks biasa. Pilih lewat header Accept (application/json, text/csv, application/xml, application/msgpack, text/plain) atau ?format=json|csv|xml|msgpack|text yang lebih diutamakan. CSV menulis satu baris per tanggal atau item daftar",
+				"text": "/month dengan ?format=text (atau Accept: text/plain) ditampilkan sebagai kisi kalender ala perintah cal: tiap hari berisi tanggal Masehi, pasaran dan tanggal Jawa, [1] menandai awal bulan Jawa dan * menandai hari libur. curl dan wget mendapat kisi ini otomatis kecuali ?format= atau header Accept lain diberikan",
+				"caldav": "Tambahkan akun CalDAV dengan URL http://host/caldav/1990-05-15/ (ganti dengan tanggal lahir) tanpa kata sandi. Server hanya bisa dibaca; PROPFIND Depth 1 pada kalender mendaftar acara tahun ini dan tahun depan"
 			}
 		}`))
 	}).Methods("GET")
@@ -109,9 +174,10 @@ func corsMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Timezone")
 
-		if r.Method == "OPTIONS" {
+		// OPTIONS ke /caldav diteruskan agar header DAV ikut terkirim
+		if r.Method == "OPTIONS" && !strings.HasPrefix(r.URL.Path, "/caldav") {
 			w.WriteHeader(http.StatusOK)
 			return
 		}
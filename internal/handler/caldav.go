@@ -0,0 +1,498 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/yuxxeun/jakal/internal/service"
+	"github.com/yuxxeun/jakal/pkg/calendar"
+	"github.com/yuxxeun/jakal/pkg/ical"
+)
+
+// CalDAVPrefix - akar server CalDAV. /caldav/ berisi kalender bersama,
+// /caldav/{birth_date}/ menambahkan kalender wetonan untuk tanggal lahir tersebut.
+const CalDAVPrefix = "/caldav/"
+
+// Rentang time-range terpanjang yang dilayani dalam satu REPORT, dan
+// panjang rentang bila klien hanya memberi start atau end
+const (
+	caldavMaxDays  = 10 * 366
+	caldavOpenDays = 366
+)
+
+// caldavLimits - keterangan batas REPORT yang ditambahkan ke
+// calendar-description setiap kalender
+var caldavLimits = fmt.Sprintf("time-range REPORT maksimal %d hari; time-range yang hanya berisi start atau end dibaca %d hari", caldavMaxDays, caldavOpenDays)
+
+var caldavDatePattern = regexp.MustCompile(`\d{4}-\d{2}-\d{2}`)
+
+// caldavCalendar - satu koleksi kalender yang hanya bisa dibaca
+type caldavCalendar struct {
+	name        string
+	displayName string
+	description string
+	perUser     bool
+	// events mengembalikan acara yang jatuh di antara first dan last
+	events func(svc *service.JavaneseCalendarService, birthDate string, first, last calendar.JDN) []ical.Event
+}
+
+var caldavCalendars = []caldavCalendar{
+	{
+		name:        "holidays",
+		displayName: "Hari Libur dan Hari Besar Jawa",
+		description: "Libur nasional, cuti bersama, 1 Sura, grebeg, selikuran dan Rebo Wekasan",
+		events: func(svc *service.JavaneseCalendarService, _ string, first, last calendar.JDN) []ical.Event {
+			var events []ical.Event
+			for _, holiday := range svc.GetHolidaysInRange(first.Time(), last.Time(), "") {
+				events = append(events, holidayEvent(holiday))
+			}
+			return events
+		},
+	},
+	{
+		name:        "pasaran",
+		displayName: "Pasaran",
+		description: "Pasaran dan tanggal Jawa setiap hari",
+		events: func(svc *service.JavaneseCalendarService, _ string, first, last calendar.JDN) []ical.Event {
+			var events []ical.Event
+			for _, jd := range svc.GetDateRange(first.Time(), last.Time()) {
+				events = append(events, ical.Event{
+					UID:         "pasaran-" + jd.GregorianDate + "@jakal",
+					Start:       calendar.JDN(jd.JulianDay),
+					Summary:     jd.Pasaran + " · " + formatJavanese(jd),
+					Description: fmt.Sprintf("%s, neptu %d, wuku %s", jd.Weton, jd.Neptu, jd.Wuku),
+					Categories:  []string{"Pasaran " + jd.Pasaran},
+				})
+			}
+			return events
+		},
+	},
+	{
+		name:        "wetonan",
+		displayName: "Wetonan",
+		description: "Weton lahir yang berulang tiap 35 hari",
+		perUser:     true,
+		events: func(svc *service.JavaneseCalendarService, birthDate string, first, last calendar.JDN) []ical.Event {
+			birth, err := svc.ParseDate(birthDate)
+			if err != nil || calendar.FromTime(birth) > last {
+				return nil
+			}
+			return []ical.Event{wetonanEvent(birthDate, svc.ConvertToJavaneseDate(birth))}
+		},
+	},
+}
+
+// caldavResource - hasil pemetaan path CalDAV: home (koleksi kalender),
+// satu kalender, atau satu objek .ics di dalam kalender
+type caldavResource struct {
+	birthDate string
+	calendar  *caldavCalendar
+	object    string
+}
+
+func (res caldavResource) homeHref() string {
+	if res.birthDate == "" {
+		return CalDAVPrefix
+	}
+	return CalDAVPrefix + res.birthDate + "/"
+}
+
+func (res caldavResource) calendarHref(cal *caldavCalendar) string {
+	return res.homeHref() + cal.name + "/"
+}
+
+func (res caldavResource) objectHref(event ical.Event) string {
+	return res.calendarHref(res.calendar) + strings.TrimSuffix(event.UID, "@jakal") + ".ics"
+}
+
+// calendars mengembalikan kalender yang ada di home ini
+func (res caldavResource) calendars() []*caldavCalendar {
+	var calendars []*caldavCalendar
+	for i := range caldavCalendars {
+		if caldavCalendars[i].perUser && res.birthDate == "" {
+			continue
+		}
+		calendars = append(calendars, &caldavCalendars[i])
+	}
+	return calendars
+}
+
+// resolveCalDAV memetakan path ke resource, false bila tidak ada
+func resolveCalDAV(svc *service.JavaneseCalendarService, path string) (caldavResource, bool) {
+	var res caldavResource
+
+	rest := strings.Trim(strings.TrimPrefix(path, strings.TrimSuffix(CalDAVPrefix, "/")), "/")
+	var segments []string
+	if rest != "" {
+		segments = strings.Split(rest, "/")
+	}
+
+	if len(segments) > 0 && caldavDatePattern.MatchString(segments[0]) {
+		if _, err := svc.ParseDate(segments[0]); err != nil {
+			return res, false
+		}
+		res.birthDate = segments[0]
+		segments = segments[1:]
+	}
+
+	if len(segments) == 0 {
+		return res, true
+	}
+	for _, cal := range res.calendars() {
+		if cal.name == segments[0] {
+			res.calendar = cal
+		}
+	}
+	if res.calendar == nil || len(segments) > 2 {
+		return res, false
+	}
+	if len(segments) == 2 {
+		if !strings.HasSuffix(segments[1], ".ics") {
+			return res, false
+		}
+		res.object = strings.TrimSuffix(segments[1], ".ics")
+	}
+	return res, true
+}
+
+// CalDAV - server CalDAV read-only: PROPFIND, REPORT (calendar-query dengan
+// time-range dan calendar-multiget), GET dan OPTIONS
+func (h *JavaneseCalendarHandler) CalDAV(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("DAV", "1, 3, calendar-access")
+	w.Header().Set("Allow", "OPTIONS, GET, HEAD, PROPFIND, REPORT")
+
+	svc, ok := h.serviceFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	res, found := resolveCalDAV(svc, r.URL.Path)
+
+	switch r.Method {
+	case http.MethodOptions:
+		w.WriteHeader(http.StatusOK)
+		return
+	case http.MethodGet, http.MethodHead, "PROPFIND", "REPORT":
+	default:
+		http.Error(w, "Kalender hanya bisa dibaca", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !found {
+		http.Error(w, "Resource tidak ditemukan", http.StatusNotFound)
+		return
+	}
+
+	switch r.Method {
+	case "PROPFIND":
+		h.caldavPropfind(w, r, svc, res)
+	case "REPORT":
+		h.caldavReport(w, r, svc, res)
+	default:
+		h.caldavGet(w, r, svc, res)
+	}
+}
+
+// CalDAVWellKnown mengarahkan /.well-known/caldav ke akar server (RFC 6764)
+func (h *JavaneseCalendarHandler) CalDAVWellKnown(w http.ResponseWriter, r *http.Request) {
+	http.Redirect(w, r, CalDAVPrefix, http.StatusMovedPermanently)
+}
+
+// defaultWindow - rentang acara bila klien tidak memberi time-range:
+// tahun ini dan tahun depan
+func defaultWindow(svc *service.JavaneseCalendarService) (first, last calendar.JDN) {
+	year := svc.Now().Year()
+	return calendar.FromGregorian(year, time.January, 1), calendar.FromGregorian(year+1, time.December, 31)
+}
+
+func (h *JavaneseCalendarHandler) caldavPropfind(w http.ResponseWriter, r *http.Request, svc *service.JavaneseCalendarService, res caldavResource) {
+	var request davPropfind
+	if err := readDAVBody(r, &request); err != nil {
+		http.Error(w, "Body PROPFIND tidak valid", http.StatusBadRequest)
+		return
+	}
+	requested := request.Prop.requestedProps()
+	depth := r.Header.Get("Depth")
+
+	var responses []davResponse
+	add := func(href string, props []davProp) {
+		if request.PropName != nil {
+			props = propNamesOnly(props)
+		}
+		found, missing := selectProps(props, requested)
+		responses = append(responses, davResponse{href: href, found: found, missing: missing})
+	}
+
+	switch {
+	case res.calendar == nil:
+		add(res.homeHref(), homeProps(res))
+		if depth != "0" {
+			for _, cal := range res.calendars() {
+				calRes := res
+				calRes.calendar = cal
+				add(res.calendarHref(cal), calendarProps(svc, calRes))
+			}
+		}
+	case res.object == "":
+		add(res.calendarHref(res.calendar), calendarProps(svc, res))
+		if depth != "0" {
+			first, last := defaultWindow(svc)
+			for _, event := range res.calendar.events(svc, res.birthDate, first, last) {
+				add(res.objectHref(event), objectProps(event, false))
+			}
+		}
+	default:
+		event, ok := findCalDAVEvent(svc, res)
+		if !ok {
+			http.Error(w, "Resource tidak ditemukan", http.StatusNotFound)
+			return
+		}
+		add(res.objectHref(event), objectProps(event, false))
+	}
+
+	writeMultistatus(w, responses)
+}
+
+func (h *JavaneseCalendarHandler) caldavReport(w http.ResponseWriter, r *http.Request, svc *service.JavaneseCalendarService, res caldavResource) {
+	if res.calendar == nil {
+		http.Error(w, "REPORT hanya didukung pada kalender", http.StatusForbidden)
+		return
+	}
+
+	var request davReport
+	if err := readDAVBody(r, &request); err != nil {
+		http.Error(w, "Body REPORT tidak valid", http.StatusBadRequest)
+		return
+	}
+	requested := request.Prop.requestedProps()
+
+	var responses []davResponse
+	switch request.XMLName {
+	case xml.Name{Space: caldavNS, Local: "calendar-query"}:
+		first, last, wantsEvents, err := queryRange(svc, request)
+		if err != nil {
+			writeDAVError(w, http.StatusForbidden, err.precondition, err.message)
+			return
+		}
+		if wantsEvents {
+			for _, event := range res.calendar.events(svc, res.birthDate, first, last) {
+				found, missing := selectProps(objectProps(event, true), requested)
+				responses = append(responses, davResponse{href: res.objectHref(event), found: found, missing: missing})
+			}
+		}
+	case xml.Name{Space: caldavNS, Local: "calendar-multiget"}:
+		for _, href := range request.Hrefs {
+			path := href
+			if parsed, err := url.Parse(href); err == nil {
+				path = parsed.Path
+			}
+			objectRes, ok := resolveCalDAV(svc, path)
+			if ok && objectRes.object != "" && objectRes.calendar == res.calendar && objectRes.birthDate == res.birthDate {
+				if event, ok := findCalDAVEvent(svc, objectRes); ok {
+					found, missing := selectProps(objectProps(event, true), requested)
+					responses = append(responses, davResponse{href: href, found: found, missing: missing})
+					continue
+				}
+			}
+			responses = append(responses, davResponse{href: href, status: http.StatusNotFound})
+		}
+	default:
+		http.Error(w, "REPORT "+request.XMLName.Local+" tidak didukung", http.StatusForbidden)
+		return
+	}
+
+	writeMultistatus(w, responses)
+}
+
+// caldavQueryError - time-range yang ditolak beserta precondition-nya
+type caldavQueryError struct {
+	precondition xml.Name
+	message      string
+}
+
+// queryRange membaca time-range pada filter VCALENDAR > VEVENT. wantsEvents
+// bernilai false bila filter meminta komponen selain VEVENT.
+func queryRange(svc *service.JavaneseCalendarService, request davReport) (first, last calendar.JDN, wantsEvents bool, err *caldavQueryError) {
+	first, last = defaultWindow(svc)
+	if request.Filter == nil {
+		return first, last, true, nil
+	}
+
+	root := request.Filter.CompFilter
+	if root.Name != "" && root.Name != "VCALENDAR" {
+		return first, last, false, nil
+	}
+
+	var event *caldavCompFilter
+	for i := range root.CompFilters {
+		if root.CompFilters[i].Name == "VEVENT" {
+			event = &root.CompFilters[i]
+		}
+	}
+	if event == nil {
+		return first, last, len(root.CompFilters) == 0, nil
+	}
+	if event.TimeRange == nil {
+		return first, last, true, nil
+	}
+
+	start, startErr := parseICalTime(event.TimeRange.Start)
+	end, endErr := parseICalTime(event.TimeRange.End)
+	if startErr != nil || endErr != nil {
+		return first, last, false, &caldavQueryError{xml.Name{Space: caldavNS, Local: "valid-filter"}, "time-range tidak valid"}
+	}
+
+	// Acara sehari penuh bersifat floating, jadi batas dibaca dalam UTC;
+	// klien di zona lain paling banyak menerima satu hari tambahan
+	switch {
+	case !start.IsZero() && !end.IsZero():
+		first, last = calendar.FromTime(start), calendar.FromTime(end.Add(-time.Nanosecond))
+	case !start.IsZero():
+		first = calendar.FromTime(start)
+		last = first + caldavOpenDays
+	case !end.IsZero():
+		last = calendar.FromTime(end.Add(-time.Nanosecond))
+		first = last - caldavOpenDays
+	}
+
+	if last.Sub(first) > caldavMaxDays {
+		return first, last, false, &caldavQueryError{xml.Name{Space: davNS, Local: "number-of-matches-within-limits"}, fmt.Sprintf("time-range maksimal %d hari", caldavMaxDays)}
+	}
+
+	// Acara di luar tahun yang didukung tidak bisa dibuka lagi lewat GET
+	// atau multiget, jadi rentangnya dipotong; rentang yang habis terpotong
+	// menghasilkan multistatus kosong
+	if lower := calendar.FromGregorian(service.MinYear, time.January, 1); first < lower {
+		first = lower
+	}
+	if upper := calendar.FromGregorian(service.MaxYear, time.December, 31); last > upper {
+		last = upper
+	}
+	return first, last, first <= last, nil
+}
+
+// parseICalTime membaca nilai DATE-TIME UTC (20250101T000000Z) atau DATE
+func parseICalTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse("20060102T150405Z", value); err == nil {
+		return t, nil
+	}
+	return time.Parse("20060102", value)
+}
+
+func (h *JavaneseCalendarHandler) caldavGet(w http.ResponseWriter, r *http.Request, svc *service.JavaneseCalendarService, res caldavResource) {
+	cal := &ical.Calendar{}
+	filename := "jakal.ics"
+
+	switch {
+	case res.calendar == nil:
+		http.Error(w, "Gunakan klien CalDAV untuk membuka koleksi ini", http.StatusMethodNotAllowed)
+		return
+	case res.object == "":
+		first, last := defaultWindow(svc)
+		cal.Name = res.calendar.displayName
+		cal.Events = res.calendar.events(svc, res.birthDate, first, last)
+		filename = res.calendar.name + ".ics"
+	default:
+		event, ok := findCalDAVEvent(svc, res)
+		if !ok {
+			http.Error(w, "Resource tidak ditemukan", http.StatusNotFound)
+			return
+		}
+		cal.Events = []ical.Event{event}
+		filename = res.object + ".ics"
+		w.Header().Set("ETag", eventETag(event))
+	}
+
+	if r.Method == http.MethodHead {
+		w.Header().Set("Content-Type", ical.ContentType)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	h.sendICS(w, cal, filename)
+}
+
+// findCalDAVEvent mencari objek berdasarkan nama berkas. Nama objek memuat
+// tanggal acaranya, jadi cukup acara di tanggal itu yang dihitung.
+func findCalDAVEvent(svc *service.JavaneseCalendarService, res caldavResource) (ical.Event, bool) {
+	dateStr := caldavDatePattern.FindString(res.object)
+	date, err := time.Parse("2006-01-02", dateStr)
+	if err != nil {
+		return ical.Event{}, false
+	}
+
+	day := calendar.FromTime(date)
+	for _, event := range res.calendar.events(svc, res.birthDate, day, day) {
+		if strings.TrimSuffix(event.UID, "@jakal") == res.object {
+			return event, true
+		}
+	}
+	return ical.Event{}, false
+}
+
+func homeProps(res caldavResource) []davProp {
+	home := davHref(res.homeHref())
+	return []davProp{
+		{xml.Name{Space: davNS, Local: "resourcetype"}, "<d:collection/>"},
+		{xml.Name{Space: davNS, Local: "displayname"}, "Jakal"},
+		{xml.Name{Space: davNS, Local: "current-user-principal"}, home},
+		{xml.Name{Space: davNS, Local: "principal-URL"}, home},
+		{xml.Name{Space: caldavNS, Local: "calendar-home-set"}, home},
+		{xml.Name{Space: davNS, Local: "current-user-privilege-set"}, "<d:privilege><d:read/></d:privilege>"},
+	}
+}
+
+func calendarProps(svc *service.JavaneseCalendarService, res caldavResource) []davProp {
+	// ctag berubah bila isi kalender dalam jendela bawaan berubah
+	first, last := defaultWindow(svc)
+	tag := fnv.New64a()
+	for _, event := range res.calendar.events(svc, res.birthDate, first, last) {
+		tag.Write([]byte(eventETag(event)))
+	}
+
+	return []davProp{
+		{xml.Name{Space: davNS, Local: "resourcetype"}, "<d:collection/><c:calendar/>"},
+		{xml.Name{Space: davNS, Local: "displayname"}, xmlEscape(res.calendar.displayName)},
+		{xml.Name{Space: davNS, Local: "current-user-principal"}, davHref(res.homeHref())},
+		{xml.Name{Space: davNS, Local: "current-user-privilege-set"}, "<d:privilege><d:read/></d:privilege>"},
+		{xml.Name{Space: davNS, Local: "supported-report-set"}, "<d:supported-report><d:report><c:calendar-query/></d:report></d:supported-report>" +
+			"<d:supported-report><d:report><c:calendar-multiget/></d:report></d:supported-report>"},
+		{xml.Name{Space: caldavNS, Local: "calendar-description"}, xmlEscape(res.calendar.description + ". Batas: " + caldavLimits)},
+		{xml.Name{Space: caldavNS, Local: "supported-calendar-component-set"}, `<c:comp name="VEVENT"/>`},
+		{xml.Name{Space: calendarServerNS, Local: "getctag"}, fmt.Sprintf("%x", tag.Sum64())},
+	}
+}
+
+// objectProps - properti satu objek .ics; calendar-data hanya disertakan
+// pada REPORT karena ukurannya
+func objectProps(event ical.Event, withData bool) []davProp {
+	props := []davProp{
+		{xml.Name{Space: davNS, Local: "resourcetype"}, ""},
+		{xml.Name{Space: davNS, Local: "getetag"}, xmlEscape(eventETag(event))},
+		{xml.Name{Space: davNS, Local: "getcontenttype"}, xmlEscape(ical.ContentType)},
+	}
+	if withData {
+		var data bytes.Buffer
+		cal := &ical.Calendar{ProdID: icalProdID, Events: []ical.Event{event}}
+		cal.Encode(&data)
+		props = append(props, davProp{xml.Name{Space: caldavNS, Local: "calendar-data"}, xmlEscape(data.String())})
+	}
+	return props
+}
+
+// eventETag diturunkan dari isi acara, bukan dari DTSTAMP, sehingga tetap
+// sama selama datanya tidak berubah
+func eventETag(event ical.Event) string {
+	hash := fnv.New64a()
+	fmt.Fprintf(hash, "%#v", event)
+	return fmt.Sprintf(`"%x"`, hash.Sum64())
+}
@@ -0,0 +1,255 @@
+package handler
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yuxxeun/jakal/internal/service"
+)
+
+// davMultistatus - bentuk multistatus yang dibaca kembali oleh test
+type davMultistatus struct {
+	Responses []struct {
+		Href      string `xml:"DAV: href"`
+		Status    string `xml:"DAV: status"`
+		Propstats []struct {
+			Prop struct {
+				Props []struct {
+					XMLName xml.Name
+					Value   string `xml:",innerxml"`
+				} `xml:",any"`
+			} `xml:"DAV: prop"`
+			Status string `xml:"DAV: status"`
+		} `xml:"DAV: propstat"`
+	} `xml:"DAV: response"`
+}
+
+// props mengembalikan isi properti per status, misalnya props["200"]["displayname"]
+func (m davMultistatus) props(i int) map[string]map[string]string {
+	byStatus := make(map[string]map[string]string)
+	for _, propstat := range m.Responses[i].Propstats {
+		code := strings.Fields(propstat.Status)[1]
+		byStatus[code] = make(map[string]string)
+		for _, prop := range propstat.Prop.Props {
+			byStatus[code][prop.XMLName.Local] = prop.Value
+		}
+	}
+	return byStatus
+}
+
+func (m davMultistatus) hrefs() []string {
+	hrefs := make([]string, len(m.Responses))
+	for i, response := range m.Responses {
+		hrefs[i] = response.Href
+	}
+	return hrefs
+}
+
+func caldavRequest(method, path, depth, body string) *httptest.ResponseRecorder {
+	h := NewJavaneseCalendarHandler(service.NewJavaneseCalendarService())
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if depth != "" {
+		req.Header.Set("Depth", depth)
+	}
+	w := httptest.NewRecorder()
+	h.CalDAV(w, req)
+	return w
+}
+
+func multistatus(t *testing.T, w *httptest.ResponseRecorder) davMultistatus {
+	t.Helper()
+	if w.Code != http.StatusMultiStatus {
+		t.Fatalf("status %d, want 207\n%s", w.Code, w.Body)
+	}
+	var m davMultistatus
+	if err := xml.Unmarshal(w.Body.Bytes(), &m); err != nil {
+		t.Fatalf("multistatus tidak valid: %v\n%s", err, w.Body)
+	}
+	return m
+}
+
+func timeRangeQuery(attributes string) string {
+	return `<c:calendar-query xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">` +
+		`<d:prop><d:getetag/></d:prop><c:filter><c:comp-filter name="VCALENDAR"><c:comp-filter name="VEVENT">` +
+		`<c:time-range ` + attributes + `/></c:comp-filter></c:comp-filter></c:filter></c:calendar-query>`
+}
+
+func TestResolveCalDAV(t *testing.T) {
+	svc := service.NewJavaneseCalendarService()
+	tests := []struct {
+		path      string
+		ok        bool
+		birthDate string
+		calendar  string
+		object    string
+	}{
+		{"/caldav", true, "", "", ""},
+		{"/caldav/", true, "", "", ""},
+		{"/caldav/holidays/", true, "", "holidays", ""},
+		{"/caldav/pasaran/pasaran-2025-06-27.ics", true, "", "pasaran", "pasaran-2025-06-27"},
+		{"/caldav/1990-05-15/", true, "1990-05-15", "", ""},
+		{"/caldav/1990-05-15/wetonan/", true, "1990-05-15", "wetonan", ""},
+		{"/caldav/wetonan/", false, "", "", ""},
+		{"/caldav/1990-02-30/", false, "", "", ""},
+		{"/caldav/unknown/", false, "", "", ""},
+		{"/caldav/pasaran/pasaran-2025-06-27.txt", false, "", "", ""},
+		{"/caldav/pasaran/a/b.ics", false, "", "", ""},
+	}
+
+	for _, tt := range tests {
+		res, ok := resolveCalDAV(svc, tt.path)
+		if ok != tt.ok {
+			t.Errorf("resolveCalDAV(%s) ok = %v, want %v", tt.path, ok, tt.ok)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		name := ""
+		if res.calendar != nil {
+			name = res.calendar.name
+		}
+		if res.birthDate != tt.birthDate || name != tt.calendar || res.object != tt.object {
+			t.Errorf("resolveCalDAV(%s) = %q, %q, %q; want %q, %q, %q",
+				tt.path, res.birthDate, name, res.object, tt.birthDate, tt.calendar, tt.object)
+		}
+	}
+}
+
+func TestCalDAVPropfindDepth(t *testing.T) {
+	tests := []struct {
+		path  string
+		depth string
+		want  []string
+	}{
+		{"/caldav/", "0", []string{"/caldav/"}},
+		{"/caldav/", "1", []string{"/caldav/", "/caldav/holidays/", "/caldav/pasaran/"}},
+		{"/caldav/1990-05-15/", "1", []string{"/caldav/1990-05-15/", "/caldav/1990-05-15/holidays/", "/caldav/1990-05-15/pasaran/", "/caldav/1990-05-15/wetonan/"}},
+		{"/caldav/1990-05-15/wetonan/", "1", []string{"/caldav/1990-05-15/wetonan/", "/caldav/1990-05-15/wetonan/wetonan-1990-05-15.ics"}},
+		{"/caldav/pasaran/", "0", []string{"/caldav/pasaran/"}},
+	}
+
+	for _, tt := range tests {
+		m := multistatus(t, caldavRequest("PROPFIND", tt.path, tt.depth, ""))
+		if got := m.hrefs(); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("PROPFIND %s Depth %s = %q, want %q", tt.path, tt.depth, got, tt.want)
+		}
+	}
+
+	if w := caldavRequest("PROPFIND", "/caldav/unknown/", "0", ""); w.Code != http.StatusNotFound {
+		t.Errorf("PROPFIND /caldav/unknown/ = %d, want 404", w.Code)
+	}
+}
+
+func TestCalDAVPropfindProps(t *testing.T) {
+	body := `<d:propfind xmlns:d="DAV:" xmlns:x="urn:example"><d:prop><d:displayname/><x:color/></d:prop></d:propfind>`
+	m := multistatus(t, caldavRequest("PROPFIND", "/caldav/holidays/", "0", body))
+	props := m.props(0)
+
+	if got := props["200"]; !reflect.DeepEqual(got, map[string]string{"displayname": "Hari Libur dan Hari Besar Jawa"}) {
+		t.Errorf("propstat 200 = %q", got)
+	}
+	if got := props["404"]; !reflect.DeepEqual(got, map[string]string{"color": ""}) {
+		t.Errorf("propstat 404 = %q", got)
+	}
+}
+
+// propname hanya mengembalikan nama properti tanpa isinya (RFC 4918 9.1)
+func TestCalDAVPropname(t *testing.T) {
+	body := `<d:propfind xmlns:d="DAV:"><d:propname/></d:propfind>`
+	m := multistatus(t, caldavRequest("PROPFIND", "/caldav/holidays/", "0", body))
+	props := m.props(0)
+
+	if len(props) != 1 || len(props["200"]) == 0 {
+		t.Fatalf("propname = %q, want one propstat 200", props)
+	}
+	for _, name := range []string{"displayname", "resourcetype", "calendar-description", "getctag"} {
+		value, ok := props["200"][name]
+		if !ok {
+			t.Errorf("propname tidak mencantumkan %s", name)
+		}
+		if value != "" {
+			t.Errorf("propname mengisi %s dengan %q", name, value)
+		}
+	}
+}
+
+func TestCalDAVMultiget(t *testing.T) {
+	body := `<c:calendar-multiget xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">` +
+		`<d:prop><d:getetag/><c:calendar-data/></d:prop>` +
+		`<d:href>/caldav/pasaran/pasaran-2025-06-27.ics</d:href>` +
+		`<d:href>/caldav/pasaran/pasaran-2025-02-30.ics</d:href>` +
+		`<d:href>/caldav/holidays/pasaran-2025-06-27.ics</d:href>` +
+		`</c:calendar-multiget>`
+	m := multistatus(t, caldavRequest("REPORT", "/caldav/pasaran/", "1", body))
+
+	if len(m.Responses) != 3 {
+		t.Fatalf("multiget memberi %d response, want 3", len(m.Responses))
+	}
+	data := m.props(0)["200"]["calendar-data"]
+	if !strings.Contains(data, "UID:pasaran-2025-06-27@jakal") || !strings.Contains(data, "DTSTART;VALUE=DATE:20250627") {
+		t.Errorf("calendar-data pasaran-2025-06-27 =\n%s", data)
+	}
+	for _, i := range []int{1, 2} {
+		if m.Responses[i].Status != "HTTP/1.1 404 Not Found" || len(m.Responses[i].Propstats) != 0 {
+			t.Errorf("%s: status %q, %d propstat; want 404 tanpa propstat", m.Responses[i].Href, m.Responses[i].Status, len(m.Responses[i].Propstats))
+		}
+	}
+}
+
+func TestCalDAVTimeRangeErrors(t *testing.T) {
+	tests := []struct {
+		attributes   string
+		precondition string
+	}{
+		{`start="20000101T000000Z" end="20250101T000000Z"`, "<d:number-of-matches-within-limits/>"},
+		{`start="x"`, "<c:valid-filter/>"},
+	}
+
+	for _, tt := range tests {
+		w := caldavRequest("REPORT", "/caldav/pasaran/", "1", timeRangeQuery(tt.attributes))
+		if w.Code != http.StatusForbidden || !strings.Contains(w.Body.String(), tt.precondition) {
+			t.Errorf("time-range %s = %d\n%s\nwant 403 dengan %s", tt.attributes, w.Code, w.Body, tt.precondition)
+		}
+	}
+
+	// Tepat di batas masih dilayani
+	w := caldavRequest("REPORT", "/caldav/holidays/", "1", timeRangeQuery(`start="20000101T000000Z" end="20100101T000000Z"`))
+	multistatus(t, w)
+}
+
+// Rentang dipotong ke tahun 1 - 9999 sehingga setiap href bisa dibuka lagi
+func TestCalDAVTimeRangeClamp(t *testing.T) {
+	tests := []struct {
+		path       string
+		attributes string
+		// first dan last kosong: cukup periksa bahwa setiap href bisa dibuka
+		first string
+		last  string
+		empty bool
+	}{
+		{"/caldav/pasaran/", `start="99991225T000000Z"`, "/caldav/pasaran/pasaran-9999-12-25.ics", "/caldav/pasaran/pasaran-9999-12-31.ics", false},
+		{"/caldav/pasaran/", `end="00010105T000000Z"`, "/caldav/pasaran/pasaran-0001-01-01.ics", "/caldav/pasaran/pasaran-0001-01-04.ics", false},
+		{"/caldav/pasaran/", `start="00000101T000000Z" end="00001231T000000Z"`, "", "", true},
+		{"/caldav/holidays/", `start="99990101T000000Z"`, "", "", false},
+	}
+
+	for _, tt := range tests {
+		hrefs := multistatus(t, caldavRequest("REPORT", tt.path, "1", timeRangeQuery(tt.attributes))).hrefs()
+		if tt.first != "" && (len(hrefs) == 0 || hrefs[0] != tt.first || hrefs[len(hrefs)-1] != tt.last) {
+			t.Errorf("REPORT %s %s = %q, want %s ... %s", tt.path, tt.attributes, hrefs, tt.first, tt.last)
+		}
+		if tt.empty != (len(hrefs) == 0) {
+			t.Errorf("REPORT %s %s = %q, kosong %v", tt.path, tt.attributes, hrefs, tt.empty)
+		}
+		for _, href := range hrefs {
+			if w := caldavRequest(http.MethodGet, href, "", ""); w.Code != http.StatusOK {
+				t.Errorf("GET %s = %d, want 200", href, w.Code)
+			}
+		}
+	}
+}
@@ -1,7 +1,7 @@
 package handler
 
 import (
-	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
@@ -10,6 +10,7 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/yuxxeun/jakal/internal/model"
 	"github.com/yuxxeun/jakal/internal/service"
+	"github.com/yuxxeun/jakal/pkg/calendar"
 )
 
 type JavaneseCalendarHandler struct {
@@ -21,29 +22,45 @@ func NewJavaneseCalendarHandler(service *service.JavaneseCalendarService) *Javan
 }
 
 func (h *JavaneseCalendarHandler) GetToday(w http.ResponseWriter, r *http.Request) {
-	today := time.Now()
-	javaneseDate := h.service.ConvertToJavaneseDate(today)
+	svc, ok := h.serviceFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	today := svc.Now()
+	javaneseDate := svc.ConvertToJavaneseDate(today)
 
 	response := model.APIResponse{
 		Status:  "success",
-		Message: "Tanggal Jawa hari ini",
+		Message: "Tanggal Jawa hari ini (" + svc.Location().String() + ")",
 		Data:    javaneseDate,
 	}
 
-	h.sendJSONResponse(w, http.StatusOK, response)
+	h.sendResponse(w, http.StatusOK, response)
 }
 
 func (h *JavaneseCalendarHandler) GetByDate(w http.ResponseWriter, r *http.Request) {
+	svc, ok := h.serviceFromRequest(w, r)
+	if !ok {
+		return
+	}
+
 	vars := mux.Vars(r)
 	dateStr := vars["date"]
 
-	date, err := time.Parse("2006-01-02", dateStr)
+	date, err := svc.ParseDate(dateStr)
 	if err != nil {
-		h.sendErrorResponse(w, http.StatusBadRequest, "Format tanggal tidak valid. Gunakan YYYY-MM-DD")
+		h.sendErrorResponse(w, http.StatusBadRequest, "Tanggal tidak valid: "+err.Error())
+		return
+	}
+
+	effective, shift, ok := h.sunsetShiftFromRequest(w, r, date)
+	if !ok {
 		return
 	}
 
-	javaneseDate := h.service.ConvertToJavaneseDate(date)
+	javaneseDate := svc.ConvertToJavaneseDate(effective)
+	javaneseDate.SunsetShift = shift
 
 	response := model.APIResponse{
 		Status:  "success",
@@ -51,26 +68,20 @@ func (h *JavaneseCalendarHandler) GetByDate(w http.ResponseWriter, r *http.Reque
 		Data:    javaneseDate,
 	}
 
-	h.sendJSONResponse(w, http.StatusOK, response)
+	h.sendResponse(w, http.StatusOK, response)
 }
 
 func (h *JavaneseCalendarHandler) FilterByWeton(w http.ResponseWriter, r *http.Request) {
+	svc, ok := h.serviceFromRequest(w, r)
+	if !ok {
+		return
+	}
+
 	vars := mux.Vars(r)
-	weton := vars["weton"]
+	weton := normalizeWeton(vars["weton"])
 	yearStr := vars["year"]
 	monthStr := vars["month"]
 
-	// Support strip dan %20 - normalize weton
-	weton = strings.ReplaceAll(weton, "-", " ")   // Convert strip to space
-	weton = strings.ReplaceAll(weton, "%20", " ") // Support legacy URL encoding
-
-	// Normalize case - capitalize each word
-	parts := strings.Fields(weton)
-	for i, part := range parts {
-		parts[i] = strings.Title(strings.ToLower(part))
-	}
-	weton = strings.Join(parts, " ")
-
 	year, err := strconv.Atoi(yearStr)
 	if err != nil {
 		h.sendErrorResponse(w, http.StatusBadRequest, "Format tahun tidak valid")
@@ -78,9 +89,7 @@ func (h *JavaneseCalendarHandler) FilterByWeton(w http.ResponseWriter, r *http.R
 	}
 
 	// Validasi tahun
-	currentYear := time.Now().Year()
-	if year < 1900 || year > currentYear+50 {
-		h.sendErrorResponse(w, http.StatusBadRequest, "Tahun harus antara 1900 - "+strconv.Itoa(currentYear+50))
+	if !h.validYear(w, year) {
 		return
 	}
 
@@ -93,7 +102,12 @@ func (h *JavaneseCalendarHandler) FilterByWeton(w http.ResponseWriter, r *http.R
 		}
 	}
 
-	dates := h.service.FilterByWeton(year, month, weton)
+	dates := svc.FilterByWeton(year, month, weton)
+
+	if wantsICS(w) {
+		h.sendICS(w, wetonCalendar(weton, dates), "weton-"+vars["weton"]+"-"+yearStr+".ics")
+		return
+	}
 
 	var message string
 	if month == 0 {
@@ -116,21 +130,34 @@ func (h *JavaneseCalendarHandler) FilterByWeton(w http.ResponseWriter, r *http.R
 		},
 	}
 
-	h.sendJSONResponse(w, http.StatusOK, response)
+	h.sendResponse(w, http.StatusOK, response)
 }
 
-func (h *JavaneseCalendarHandler) GetDateRange(w http.ResponseWriter, r *http.Request) {
+// FilterByWetonRange - semua tanggal berweton tertentu dalam rentang hingga
+// 200 tahun, misalnya setiap Jumat Kliwon dari 1900 sampai 2100
+func (h *JavaneseCalendarHandler) FilterByWetonRange(w http.ResponseWriter, r *http.Request) {
+	svc, ok := h.serviceFromRequest(w, r)
+	if !ok {
+		return
+	}
+
 	vars := mux.Vars(r)
+	weton := normalizeWeton(vars["weton"])
 	startStr := vars["start"]
 	endStr := vars["end"]
 
-	start, err := time.Parse("2006-01-02", startStr)
+	if !svc.IsValidWeton(weton) {
+		h.sendErrorResponse(w, http.StatusBadRequest, "Weton tidak dikenal. Contoh: jumat-kliwon")
+		return
+	}
+
+	start, err := svc.ParseDate(startStr)
 	if err != nil {
 		h.sendErrorResponse(w, http.StatusBadRequest, "Format tanggal start tidak valid")
 		return
 	}
 
-	end, err := time.Parse("2006-01-02", endStr)
+	end, err := svc.ParseDate(endStr)
 	if err != nil {
 		h.sendErrorResponse(w, http.StatusBadRequest, "Format tanggal end tidak valid")
 		return
@@ -141,26 +168,48 @@ func (h *JavaneseCalendarHandler) GetDateRange(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	maxDays := 365
-	if int(end.Sub(start).Hours()/24) > maxDays {
-		h.sendErrorResponse(w, http.StatusBadRequest, "Range tanggal maksimal 1 tahun")
+	if end.Year()-start.Year() > 200 {
+		h.sendErrorResponse(w, http.StatusBadRequest, "Range tanggal maksimal 200 tahun")
 		return
 	}
 
-	dateRange := h.service.GetDateRange(start, end)
+	dates := svc.FilterByWetonRange(start, end, weton)
+
+	if wantsICS(w) {
+		h.sendICS(w, wetonCalendar(weton, dates), "weton-"+vars["weton"]+".ics")
+		return
+	}
 
 	response := model.APIResponse{
 		Status:  "success",
-		Message: "Range tanggal Jawa dari " + startStr + " hingga " + endStr,
-		Data:    dateRange,
+		Message: "Daftar tanggal untuk weton " + weton + " dari " + startStr + " hingga " + endStr,
+		Data: map[string]interface{}{
+			"weton":       weton,
+			"start":       startStr,
+			"end":         endStr,
+			"total_dates": len(dates),
+			"dates":       dates,
+		},
 	}
 
-	h.sendJSONResponse(w, http.StatusOK, response)
+	h.sendResponse(w, http.StatusOK, response)
 }
 
-func (h *JavaneseCalendarHandler) GetByYear(w http.ResponseWriter, r *http.Request) {
+func (h *JavaneseCalendarHandler) FilterByWuku(w http.ResponseWriter, r *http.Request) {
+	svc, ok := h.serviceFromRequest(w, r)
+	if !ok {
+		return
+	}
+
 	vars := mux.Vars(r)
 	yearStr := vars["year"]
+	monthStr := vars["month"]
+
+	wuku, valid := service.NormalizeWuku(vars["wuku"])
+	if !valid {
+		h.sendErrorResponse(w, http.StatusBadRequest, "Wuku tidak dikenal. Contoh: sinta, julung-wangi, watugunung")
+		return
+	}
 
 	year, err := strconv.Atoi(yearStr)
 	if err != nil {
@@ -168,229 +217,979 @@ func (h *JavaneseCalendarHandler) GetByYear(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	currentYear := time.Now().Year()
-	if year < 1900 || year > currentYear+50 {
-		h.sendErrorResponse(w, http.StatusBadRequest, "Tahun harus antara 1900 - "+strconv.Itoa(currentYear+50))
+	// Validasi tahun
+	if !h.validYear(w, year) {
 		return
 	}
 
-	yearData := h.service.GetYearData(year)
+	month := 0
+	if monthStr != "" {
+		month, err = strconv.Atoi(monthStr)
+		if err != nil || month < 1 || month > 12 {
+			h.sendErrorResponse(w, http.StatusBadRequest, "Format bulan tidak valid (1-12)")
+			return
+		}
+	}
+
+	dates := svc.FilterByWuku(year, month, wuku)
+
+	var message string
+	if month == 0 {
+		message = "Daftar tanggal untuk wuku " + wuku + " di tahun " + yearStr
+	} else {
+		monthNames := []string{"", "Januari", "Februari", "Maret", "April", "Mei", "Juni",
+			"Juli", "Agustus", "September", "Oktober", "November", "Desember"}
+		message = "Daftar tanggal untuk wuku " + wuku + " di bulan " + monthNames[month] + " " + yearStr
+	}
 
 	response := model.APIResponse{
 		Status:  "success",
-		Message: "Data tanggal Jawa untuk tahun " + yearStr,
-		Data:    yearData,
+		Message: message,
+		Data: map[string]interface{}{
+			"wuku":        wuku,
+			"year":        year,
+			"month":       month,
+			"total_dates": len(dates),
+			"dates":       dates,
+		},
 	}
 
-	h.sendJSONResponse(w, http.StatusOK, response)
+	h.sendResponse(w, http.StatusOK, response)
 }
 
-func (h *JavaneseCalendarHandler) GetByMonth(w http.ResponseWriter, r *http.Request) {
+func (h *JavaneseCalendarHandler) FilterByWewaran(w http.ResponseWriter, r *http.Request) {
+	svc, ok := h.serviceFromRequest(w, r)
+	if !ok {
+		return
+	}
+
 	vars := mux.Vars(r)
 	yearStr := vars["year"]
 	monthStr := vars["month"]
 
+	cycle, value, valid := svc.NormalizeWewaran(vars["cycle"], vars["value"])
+	if !valid {
+		h.sendErrorResponse(w, http.StatusBadRequest, "Wewaran tidak dikenal. Contoh: sadwara/tungle, padewan/sri, padangon/dangu")
+		return
+	}
+
 	year, err := strconv.Atoi(yearStr)
 	if err != nil {
 		h.sendErrorResponse(w, http.StatusBadRequest, "Format tahun tidak valid")
 		return
 	}
 
-	month, err := strconv.Atoi(monthStr)
-	if err != nil || month < 1 || month > 12 {
-		h.sendErrorResponse(w, http.StatusBadRequest, "Format bulan tidak valid (1-12)")
+	// Validasi tahun
+	if !h.validYear(w, year) {
 		return
 	}
 
-	monthData := h.service.GetMonthData(year, month)
+	month := 0
+	if monthStr != "" {
+		month, err = strconv.Atoi(monthStr)
+		if err != nil || month < 1 || month > 12 {
+			h.sendErrorResponse(w, http.StatusBadRequest, "Format bulan tidak valid (1-12)")
+			return
+		}
+	}
+
+	dates := svc.FilterByWewaran(year, month, cycle, value)
+
+	var message string
+	if month == 0 {
+		message = "Daftar tanggal untuk " + cycle + " " + value + " di tahun " + yearStr
+	} else {
+		monthNames := []string{"", "Januari", "Februari", "Maret", "April", "Mei", "Juni",
+			"Juli", "Agustus", "September", "Oktober", "November", "Desember"}
+		message = "Daftar tanggal untuk " + cycle + " " + value + " di bulan " + monthNames[month] + " " + yearStr
+	}
 
 	response := model.APIResponse{
 		Status:  "success",
-		Message: "Data tanggal Jawa untuk bulan " + monthStr + " tahun " + yearStr,
-		Data:    monthData,
+		Message: message,
+		Data: map[string]interface{}{
+			"cycle":       cycle,
+			"value":       value,
+			"year":        year,
+			"month":       month,
+			"total_dates": len(dates),
+			"dates":       dates,
+		},
 	}
 
-	h.sendJSONResponse(w, http.StatusOK, response)
+	h.sendResponse(w, http.StatusOK, response)
 }
 
-func (h *JavaneseCalendarHandler) GetWeton(w http.ResponseWriter, r *http.Request) {
+func (h *JavaneseCalendarHandler) GetDateRange(w http.ResponseWriter, r *http.Request) {
+	svc, ok := h.serviceFromRequest(w, r)
+	if !ok {
+		return
+	}
+
 	vars := mux.Vars(r)
-	dateStr := vars["date"]
+	startStr := vars["start"]
+	endStr := vars["end"]
 
-	date, err := time.Parse("2006-01-02", dateStr)
+	start, err := svc.ParseDate(startStr)
 	if err != nil {
-		h.sendErrorResponse(w, http.StatusBadRequest, "Format tanggal tidak valid. Gunakan YYYY-MM-DD")
+		h.sendErrorResponse(w, http.StatusBadRequest, "Format tanggal start tidak valid")
+		return
+	}
+
+	end, err := svc.ParseDate(endStr)
+	if err != nil {
+		h.sendErrorResponse(w, http.StatusBadRequest, "Format tanggal end tidak valid")
+		return
+	}
+
+	if start.After(end) {
+		h.sendErrorResponse(w, http.StatusBadRequest, "Tanggal start tidak boleh lebih besar dari end")
+		return
+	}
+
+	maxDays := 365
+	if calendar.FromTime(end).Sub(calendar.FromTime(start)) > maxDays {
+		h.sendErrorResponse(w, http.StatusBadRequest, "Range tanggal maksimal 1 tahun")
 		return
 	}
 
-	weton := h.service.GetWetonByDate(date)
+	dateRange := svc.GetDateRange(start, end)
 
 	response := model.APIResponse{
 		Status:  "success",
-		Message: "Weton untuk tanggal " + dateStr,
-		Data: map[string]interface{}{
-			"date":  dateStr,
-			"weton": weton,
-		},
+		Message: "Range tanggal Jawa dari " + startStr + " hingga " + endStr,
+		Data:    dateRange,
 	}
 
-	h.sendJSONResponse(w, http.StatusOK, response)
+	h.sendResponse(w, http.StatusOK, response)
 }
 
-func (h *JavaneseCalendarHandler) GetNeptu(w http.ResponseWriter, r *http.Request) {
+func (h *JavaneseCalendarHandler) GetByYear(w http.ResponseWriter, r *http.Request) {
+	svc, ok := h.serviceFromRequest(w, r)
+	if !ok {
+		return
+	}
+
 	vars := mux.Vars(r)
-	dateStr := vars["date"]
+	yearStr := vars["year"]
 
-	date, err := time.Parse("2006-01-02", dateStr)
+	year, err := strconv.Atoi(yearStr)
 	if err != nil {
-		h.sendErrorResponse(w, http.StatusBadRequest, "Format tanggal tidak valid. Gunakan YYYY-MM-DD")
+		h.sendErrorResponse(w, http.StatusBadRequest, "Format tahun tidak valid")
+		return
+	}
+
+	if !h.validYear(w, year) {
 		return
 	}
 
-	neptu := h.service.GetNeptuByDate(date)
-	javaneseDate := h.service.ConvertToJavaneseDate(date)
+	yearData := svc.GetYearData(year)
+
+	if wantsICS(w) {
+		h.sendICS(w, datesCalendar("Kalender Jawa "+yearStr, yearData.Dates), "jawa-"+yearStr+".ics")
+		return
+	}
 
 	response := model.APIResponse{
 		Status:  "success",
-		Message: "Neptu untuk tanggal " + dateStr,
-		Data: map[string]interface{}{
-			"date":          dateStr,
-			"weton":         javaneseDate.Weton,
-			"day":           javaneseDate.Day,
-			"pasaran":       javaneseDate.Pasaran,
-			"neptu":         neptu,
-			"day_neptu":     h.service.GetDayNeptu(javaneseDate.Day),
-			"pasaran_neptu": h.service.GetPasaranNeptu(javaneseDate.Pasaran),
-		},
+		Message: "Data tanggal Jawa untuk tahun " + yearStr,
+		Data:    yearData,
 	}
 
-	h.sendJSONResponse(w, http.StatusOK, response)
+	h.sendResponse(w, http.StatusOK, response)
 }
 
-func (h *JavaneseCalendarHandler) GetWetonCompatibility(w http.ResponseWriter, r *http.Request) {
+func (h *JavaneseCalendarHandler) GetByMonth(w http.ResponseWriter, r *http.Request) {
+	svc, ok := h.serviceFromRequest(w, r)
+	if !ok {
+		return
+	}
+
 	vars := mux.Vars(r)
-	date1Str := vars["date1"]
-	date2Str := vars["date2"]
+	yearStr := vars["year"]
+	monthStr := vars["month"]
 
-	date1, err := time.Parse("2006-01-02", date1Str)
+	year, err := strconv.Atoi(yearStr)
 	if err != nil {
-		h.sendErrorResponse(w, http.StatusBadRequest, "Format tanggal pertama tidak valid")
+		h.sendErrorResponse(w, http.StatusBadRequest, "Format tahun tidak valid")
 		return
 	}
 
-	date2, err := time.Parse("2006-01-02", date2Str)
-	if err != nil {
-		h.sendErrorResponse(w, http.StatusBadRequest, "Format tanggal kedua tidak valid")
+	month, err := strconv.Atoi(monthStr)
+	if err != nil || month < 1 || month > 12 {
+		h.sendErrorResponse(w, http.StatusBadRequest, "Format bulan tidak valid (1-12)")
+		return
+	}
+
+	if !h.validYear(w, year) {
 		return
 	}
 
-	javaneseDate1 := h.service.ConvertToJavaneseDate(date1)
-	javaneseDate2 := h.service.ConvertToJavaneseDate(date2)
+	monthData := svc.GetMonthData(year, month)
 
-	compatibility := h.service.CalculateWetonCompatibility(javaneseDate1.Weton, javaneseDate2.Weton)
+	if wantsICS(w) {
+		h.sendICS(w, datesCalendar(fmt.Sprintf("Kalender Jawa %s-%02d", yearStr, month), monthData.Dates), fmt.Sprintf("jawa-%s-%02d.ics", yearStr, month))
+		return
+	}
 
 	response := model.APIResponse{
 		Status:  "success",
-		Message: "Kecocokan weton untuk " + date1Str + " dan " + date2Str,
-		Data: model.WetonCompatibility{
-			Weton1:        javaneseDate1.Weton,
-			Weton2:        javaneseDate2.Weton,
-			Neptu1:        javaneseDate1.Neptu,
-			Neptu2:        javaneseDate2.Neptu,
-			TotalNeptu:    javaneseDate1.Neptu + javaneseDate2.Neptu,
-			Compatibility: compatibility,
-			Description:   "Kecocokan berdasarkan perhitungan weton Jawa",
-		},
+		Message: "Data tanggal Jawa untuk bulan " + monthStr + " tahun " + yearStr,
+		Data:    monthData,
 	}
 
-	h.sendJSONResponse(w, http.StatusOK, response)
+	// Format bawaan bergantung pada User-Agent, jadi cache perlu membedakannya
+	w.Header().Add("Vary", "User-Agent")
+	if terminalClient(r) {
+		h.sendFormatted(w, http.StatusOK, textFormat, response)
+		return
+	}
+
+	h.sendResponse(w, http.StatusOK, response)
 }
 
-func (h *JavaneseCalendarHandler) GetGoodDays(w http.ResponseWriter, r *http.Request) {
+func (h *JavaneseCalendarHandler) GetJavaneseYear(w http.ResponseWriter, r *http.Request) {
+	svc, ok := h.serviceFromRequest(w, r)
+	if !ok {
+		return
+	}
+
 	vars := mux.Vars(r)
-	birthDateStr := vars["birth_date"]
-	targetYearStr := vars["target_year"]
+	yearStr := vars["year"]
 
-	birthDate, err := time.Parse("2006-01-02", birthDateStr)
+	year, err := strconv.Atoi(yearStr)
 	if err != nil {
-		h.sendErrorResponse(w, http.StatusBadRequest, "Format tanggal lahir tidak valid")
+		h.sendErrorResponse(w, http.StatusBadRequest, "Format tahun Jawa tidak valid")
 		return
 	}
 
-	targetYear, err := strconv.Atoi(targetYearStr)
-	if err != nil {
-		h.sendErrorResponse(w, http.StatusBadRequest, "Format tahun target tidak valid")
+	if year < 1555 || year > service.MaxJavaneseYear {
+		h.sendErrorResponse(w, http.StatusBadRequest, "Tahun Jawa harus antara 1555 - "+strconv.Itoa(service.MaxJavaneseYear))
 		return
 	}
 
-	birthWeton := h.service.GetWetonByDate(birthDate)
-	goodDays := h.service.GetGoodDays(birthDate, targetYear)
+	yearData := svc.GetJavaneseYearData(year)
 
 	response := model.APIResponse{
 		Status:  "success",
-		Message: "Hari baik untuk weton " + birthWeton + " di tahun " + targetYearStr,
-		Data: model.GoodDaysResponse{
-			BirthWeton: birthWeton,
-			Year:       targetYear,
-			GoodDays:   goodDays,
-			TotalDays:  len(goodDays),
-		},
+		Message: "Data tahun Jawa " + yearStr + " (" + yearData.YearName + ")",
+		Data:    yearData,
 	}
 
-	h.sendJSONResponse(w, http.StatusOK, response)
+	h.sendResponse(w, http.StatusOK, response)
 }
 
-// GetAllWeton - menampilkan semua kemungkinan weton
-func (h *JavaneseCalendarHandler) GetAllWeton(w http.ResponseWriter, r *http.Request) {
-	wetons := h.service.GetAllPossibleWeton()
+func (h *JavaneseCalendarHandler) GetPranataMangsa(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	yearStr := vars["year"]
+
+	year, err := strconv.Atoi(yearStr)
+	if err != nil {
+		h.sendErrorResponse(w, http.StatusBadRequest, "Format tahun tidak valid")
+		return
+	}
+
+	if !h.validYear(w, year) {
+		return
+	}
+
+	mangsas := h.service.GetPranataMangsa(year)
 
 	response := model.APIResponse{
 		Status:  "success",
-		Message: "Daftar semua kemungkinan weton dalam kalender Jawa",
+		Message: "Pranata Mangsa untuk tahun " + yearStr,
 		Data: map[string]interface{}{
-			"total_weton": len(wetons),
-			"wetons":      wetons,
+			"year":         year,
+			"total_mangsa": len(mangsas),
+			"mangsa":       mangsas,
 		},
 	}
 
-	h.sendJSONResponse(w, http.StatusOK, response)
+	h.sendResponse(w, http.StatusOK, response)
 }
 
-// GetWetonStatistics - statistik weton dalam periode tertentu
-func (h *JavaneseCalendarHandler) GetWetonStatistics(w http.ResponseWriter, r *http.Request) {
+// GetFromJavanese - konversi tanggal Jawa ke Masehi, beserta hasil menurut kurup lain
+func (h *JavaneseCalendarHandler) GetFromJavanese(w http.ResponseWriter, r *http.Request) {
+	svc, ok := h.serviceFromRequest(w, r)
+	if !ok {
+		return
+	}
+
 	vars := mux.Vars(r)
-	startStr := vars["start"]
-	endStr := vars["end"]
+	yearStr := vars["year"]
 
-	start, err := time.Parse("2006-01-02", startStr)
+	year, err := strconv.Atoi(yearStr)
 	if err != nil {
-		h.sendErrorResponse(w, http.StatusBadRequest, "Format tanggal start tidak valid")
+		h.sendErrorResponse(w, http.StatusBadRequest, "Format tahun Jawa tidak valid")
 		return
 	}
 
-	end, err := time.Parse("2006-01-02", endStr)
-	if err != nil {
-		h.sendErrorResponse(w, http.StatusBadRequest, "Format tanggal end tidak valid")
+	if year < 1555 || year > service.MaxJavaneseYear {
+		h.sendErrorResponse(w, http.StatusBadRequest, "Tahun Jawa harus antara 1555 - "+strconv.Itoa(service.MaxJavaneseYear))
 		return
 	}
 
-	if start.After(end) {
-		h.sendErrorResponse(w, http.StatusBadRequest, "Tanggal start tidak boleh lebih besar dari end")
+	month, valid := service.ParseJavaneseMonth(vars["month"])
+	if !valid {
+		h.sendErrorResponse(w, http.StatusBadRequest, "Bulan Jawa tidak valid. Gunakan 1-12 atau nama bulan (sura, sapar, mulud, ...)")
 		return
 	}
 
-	// Maksimal 2 tahun untuk menghindari overload
-	maxDays := 730
-	if int(end.Sub(start).Hours()/24) > maxDays {
-		h.sendErrorResponse(w, http.StatusBadRequest, "Range tanggal maksimal 2 tahun")
+	day, err := strconv.Atoi(vars["day"])
+	if err != nil {
+		h.sendErrorResponse(w, http.StatusBadRequest, "Format tanggal Jawa tidak valid")
 		return
 	}
 
-	dateRange := h.service.GetDateRange(start, end)
+	date, err := svc.ConvertFromJavaneseDate(year, month, day)
+	if err != nil {
+		h.sendErrorResponse(w, http.StatusBadRequest, "Tanggal Jawa tidak valid: "+err.Error())
+		return
+	}
 
-	// Hitung statistik
-	wetonCount := make(map[string]int)
-	dayCount := make(map[string]int)
+	javaneseDate := svc.ConvertToJavaneseDate(date)
+
+	alternatives := make(map[string]string)
+	for _, kurup := range []service.Kurup{service.KurupAboge, service.KurupAsapon, service.KurupAnenge} {
+		if alternative, err := svc.WithKurup(kurup).ConvertFromJavaneseDate(year, month, day); err == nil {
+			alternatives[string(kurup)] = alternative.Format("2006-01-02")
+		}
+	}
+
+	response := model.APIResponse{
+		Status:  "success",
+		Message: "Tanggal Masehi untuk " + strconv.Itoa(day) + " " + javaneseDate.JavaneseMonthName + " " + yearStr,
+		Data: map[string]interface{}{
+			"gregorian_date": javaneseDate.GregorianDate,
+			"javanese_date":  javaneseDate,
+			"by_kurup":       alternatives,
+		},
+	}
+
+	h.sendResponse(w, http.StatusOK, response)
+}
+
+// CompareKurup - membandingkan tanggal Jawa dari dua petungan kurup dalam range tertentu
+func (h *JavaneseCalendarHandler) CompareKurup(w http.ResponseWriter, r *http.Request) {
+	svc, ok := h.serviceFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	vars := mux.Vars(r)
+	startStr := vars["start"]
+	endStr := vars["end"]
+
+	kurup1, err := service.ParseKurup(vars["kurup1"])
+	if err != nil {
+		h.sendErrorResponse(w, http.StatusBadRequest, "Kurup pertama tidak dikenal. Gunakan asapon, aboge atau anenge")
+		return
+	}
+
+	kurup2, err := service.ParseKurup(vars["kurup2"])
+	if err != nil {
+		h.sendErrorResponse(w, http.StatusBadRequest, "Kurup kedua tidak dikenal. Gunakan asapon, aboge atau anenge")
+		return
+	}
+
+	start, err := svc.ParseDate(startStr)
+	if err != nil {
+		h.sendErrorResponse(w, http.StatusBadRequest, "Format tanggal start tidak valid")
+		return
+	}
+
+	end, err := svc.ParseDate(endStr)
+	if err != nil {
+		h.sendErrorResponse(w, http.StatusBadRequest, "Format tanggal end tidak valid")
+		return
+	}
+
+	if start.After(end) {
+		h.sendErrorResponse(w, http.StatusBadRequest, "Tanggal start tidak boleh lebih besar dari end")
+		return
+	}
+
+	maxDays := 365
+	if calendar.FromTime(end).Sub(calendar.FromTime(start)) > maxDays {
+		h.sendErrorResponse(w, http.StatusBadRequest, "Range tanggal maksimal 1 tahun")
+		return
+	}
+
+	comparison := svc.CompareKurup(start, end, kurup1, kurup2)
+
+	response := model.APIResponse{
+		Status:  "success",
+		Message: "Perbandingan kurup " + comparison.Kurup1 + " dan " + comparison.Kurup2 + " dari " + startStr + " hingga " + endStr,
+		Data:    comparison,
+	}
+
+	h.sendResponse(w, http.StatusOK, response)
+}
+
+func (h *JavaneseCalendarHandler) GetWeton(w http.ResponseWriter, r *http.Request) {
+	svc, ok := h.serviceFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	vars := mux.Vars(r)
+	dateStr := vars["date"]
+
+	date, err := svc.ParseDate(dateStr)
+	if err != nil {
+		h.sendErrorResponse(w, http.StatusBadRequest, "Tanggal tidak valid: "+err.Error())
+		return
+	}
+
+	effective, shift, ok := h.sunsetShiftFromRequest(w, r, date)
+	if !ok {
+		return
+	}
+
+	weton := svc.GetWetonByDate(effective)
+
+	response := model.APIResponse{
+		Status:  "success",
+		Message: "Weton untuk tanggal " + dateStr,
+		Data: map[string]interface{}{
+			"date":         dateStr,
+			"weton":        weton,
+			"sunset_shift": shift,
+		},
+	}
+
+	h.sendResponse(w, http.StatusOK, response)
+}
+
+func (h *JavaneseCalendarHandler) GetNeptu(w http.ResponseWriter, r *http.Request) {
+	svc, ok := h.serviceFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	vars := mux.Vars(r)
+	dateStr := vars["date"]
+
+	date, err := svc.ParseDate(dateStr)
+	if err != nil {
+		h.sendErrorResponse(w, http.StatusBadRequest, "Tanggal tidak valid: "+err.Error())
+		return
+	}
+
+	effective, shift, ok := h.sunsetShiftFromRequest(w, r, date)
+	if !ok {
+		return
+	}
+
+	neptu := svc.GetNeptuByDate(effective)
+	javaneseDate := svc.ConvertToJavaneseDate(effective)
+
+	response := model.APIResponse{
+		Status:  "success",
+		Message: "Neptu untuk tanggal " + dateStr,
+		Data: map[string]interface{}{
+			"date":          dateStr,
+			"weton":         javaneseDate.Weton,
+			"day":           javaneseDate.Day,
+			"pasaran":       javaneseDate.Pasaran,
+			"neptu":         neptu,
+			"day_neptu":     svc.GetDayNeptu(javaneseDate.Day),
+			"pasaran_neptu": svc.GetPasaranNeptu(javaneseDate.Pasaran),
+			"sunset_shift":  shift,
+		},
+	}
+
+	h.sendResponse(w, http.StatusOK, response)
+}
+
+func (h *JavaneseCalendarHandler) GetWetonCompatibility(w http.ResponseWriter, r *http.Request) {
+	svc, ok := h.serviceFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	vars := mux.Vars(r)
+	date1Str := vars["date1"]
+	date2Str := vars["date2"]
+
+	date1, err := svc.ParseDate(date1Str)
+	if err != nil {
+		h.sendErrorResponse(w, http.StatusBadRequest, "Format tanggal pertama tidak valid")
+		return
+	}
+
+	date2, err := svc.ParseDate(date2Str)
+	if err != nil {
+		h.sendErrorResponse(w, http.StatusBadRequest, "Format tanggal kedua tidak valid")
+		return
+	}
+
+	javaneseDate1 := svc.ConvertToJavaneseDate(date1)
+	javaneseDate2 := svc.ConvertToJavaneseDate(date2)
+
+	compatibility := svc.CalculateWetonCompatibility(javaneseDate1.Weton, javaneseDate2.Weton)
+
+	response := model.APIResponse{
+		Status:  "success",
+		Message: "Kecocokan weton untuk " + date1Str + " dan " + date2Str,
+		Data:    compatibility,
+	}
+
+	h.sendResponse(w, http.StatusOK, response)
+}
+
+func (h *JavaneseCalendarHandler) GetGoodDays(w http.ResponseWriter, r *http.Request) {
+	svc, ok := h.serviceFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	vars := mux.Vars(r)
+	birthDateStr := vars["birth_date"]
+	targetYearStr := vars["target_year"]
+
+	birthDate, err := svc.ParseDate(birthDateStr)
+	if err != nil {
+		h.sendErrorResponse(w, http.StatusBadRequest, "Format tanggal lahir tidak valid")
+		return
+	}
+
+	targetYear, err := strconv.Atoi(targetYearStr)
+	if err != nil {
+		h.sendErrorResponse(w, http.StatusBadRequest, "Format tahun target tidak valid")
+		return
+	}
+	if !h.validYear(w, targetYear) {
+		return
+	}
+
+	// Tujuan bisa lewat path /good-days/{purpose}/... atau query ?purpose=
+	purpose := vars["purpose"]
+	if purpose == "" {
+		purpose = r.URL.Query().Get("purpose")
+	}
+	if purpose == "" {
+		purpose = service.PurposeUmum
+	}
+
+	goodDays, err := svc.GetGoodDays(birthDate, targetYear, purpose)
+	if err != nil {
+		h.sendErrorResponse(w, http.StatusBadRequest, "Tujuan tidak dikenal. Gunakan salah satu: "+strings.Join(service.GoodDayPurposes(), ", "))
+		return
+	}
+
+	birthWeton := svc.GetWetonByDate(birthDate)
+
+	if wantsICS(w) {
+		h.sendICS(w, goodDaysCalendar("Hari baik "+purpose+" "+targetYearStr+" untuk "+birthWeton, purpose, goodDays), "hari-baik-"+purpose+"-"+targetYearStr+".ics")
+		return
+	}
+
+	response := model.APIResponse{
+		Status:  "success",
+		Message: "Hari baik " + purpose + " untuk weton " + birthWeton + " di tahun " + targetYearStr,
+		Data: model.GoodDaysResponse{
+			BirthWeton: birthWeton,
+			Purpose:    purpose,
+			Year:       targetYear,
+			GoodDays:   goodDays,
+			TotalDays:  len(goodDays),
+		},
+	}
+
+	h.sendResponse(w, http.StatusOK, response)
+}
+
+// GetAnalysisByDate - analisis watak weton untuk tanggal lahir tertentu
+func (h *JavaneseCalendarHandler) GetAnalysisByDate(w http.ResponseWriter, r *http.Request) {
+	svc, ok := h.serviceFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	vars := mux.Vars(r)
+	dateStr := vars["date"]
+
+	date, err := svc.ParseDate(dateStr)
+	if err != nil {
+		h.sendErrorResponse(w, http.StatusBadRequest, "Tanggal tidak valid: "+err.Error())
+		return
+	}
+
+	effective, shift, ok := h.sunsetShiftFromRequest(w, r, date)
+	if !ok {
+		return
+	}
+
+	weton := svc.GetWetonByDate(effective)
+	analysis, primbon, _ := svc.AnalyzeWeton(weton)
+
+	response := model.APIResponse{
+		Status:  "success",
+		Message: "Analisis weton " + weton + " untuk tanggal " + dateStr,
+		Data: map[string]interface{}{
+			"date":         dateStr,
+			"analysis":     analysis,
+			"primbon":      primbon,
+			"sunset_shift": shift,
+		},
+	}
+
+	h.sendResponse(w, http.StatusOK, response)
+}
+
+// GetAnalysisByWeton - analisis watak untuk weton tertentu (support strip: selasa-legi)
+func (h *JavaneseCalendarHandler) GetAnalysisByWeton(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	weton := normalizeWeton(vars["weton"])
+
+	analysis, primbon, found := h.service.AnalyzeWeton(weton)
+	if !found {
+		h.sendErrorResponse(w, http.StatusBadRequest, "Weton tidak dikenal. Contoh: selasa-legi")
+		return
+	}
+
+	response := model.APIResponse{
+		Status:  "success",
+		Message: "Analisis weton " + weton,
+		Data: map[string]interface{}{
+			"analysis": analysis,
+			"primbon":  primbon,
+		},
+	}
+
+	h.sendResponse(w, http.StatusOK, response)
+}
+
+// GetWeddingDates - rekomendasi tanggal pernikahan berdasarkan weton kedua calon
+func (h *JavaneseCalendarHandler) GetWeddingDates(w http.ResponseWriter, r *http.Request) {
+	svc, ok := h.serviceFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	vars := mux.Vars(r)
+	yearStr := vars["year"]
+
+	brideBirth, err := svc.ParseDate(vars["bride_birth"])
+	if err != nil {
+		h.sendErrorResponse(w, http.StatusBadRequest, "Format tanggal lahir pengantin putri tidak valid")
+		return
+	}
+
+	groomBirth, err := svc.ParseDate(vars["groom_birth"])
+	if err != nil {
+		h.sendErrorResponse(w, http.StatusBadRequest, "Format tanggal lahir pengantin putra tidak valid")
+		return
+	}
+
+	year, err := strconv.Atoi(yearStr)
+	if err != nil {
+		h.sendErrorResponse(w, http.StatusBadRequest, "Format tahun tidak valid")
+		return
+	}
+
+	if !h.validYear(w, year) {
+		return
+	}
+
+	// Larangan bulan dari keluarga, misalnya ?exclude_months=sela,besar
+	var excludedMonths []int
+	if excludeStr := r.URL.Query().Get("exclude_months"); excludeStr != "" {
+		for _, value := range strings.Split(excludeStr, ",") {
+			month, valid := service.ParseJavaneseMonth(strings.TrimSpace(value))
+			if !valid {
+				h.sendErrorResponse(w, http.StatusBadRequest, "Bulan Jawa pada exclude_months tidak valid: "+value)
+				return
+			}
+			excludedMonths = append(excludedMonths, month)
+		}
+	}
+
+	weddingDates := svc.GetWeddingDates(brideBirth, groomBirth, year, excludedMonths)
+
+	response := model.APIResponse{
+		Status:  "success",
+		Message: "Rekomendasi tanggal pernikahan " + weddingDates.BrideWeton + " dan " + weddingDates.GroomWeton + " di tahun " + yearStr,
+		Data:    weddingDates,
+	}
+
+	h.sendResponse(w, http.StatusOK, response)
+}
+
+// GetSlametan - tanggal peringatan kematian (nelung dina sampai nyewu),
+// opsional ?time=HH:MM karena kematian setelah maghrib dihitung hari berikutnya
+func (h *JavaneseCalendarHandler) GetSlametan(w http.ResponseWriter, r *http.Request) {
+	svc, ok := h.serviceFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	vars := mux.Vars(r)
+	dateStr := vars["date_of_death"]
+
+	deathDate, err := svc.ParseDate(dateStr)
+	if err != nil {
+		h.sendErrorResponse(w, http.StatusBadRequest, "Format tanggal wafat tidak valid. Gunakan YYYY-MM-DD")
+		return
+	}
+
+	_, shift, ok := h.sunsetShiftFromRequest(w, r, deathDate)
+	if !ok {
+		return
+	}
+
+	slametan, err := svc.GetSlametan(deathDate, shift)
+	if err != nil {
+		h.sendErrorResponse(w, http.StatusBadRequest, "Tanggal slametan tidak dapat dihitung: "+err.Error())
+		return
+	}
+
+	response := model.APIResponse{
+		Status:  "success",
+		Message: "Tanggal slametan untuk wafat " + dateStr,
+		Data:    slametan,
+	}
+
+	h.sendResponse(w, http.StatusOK, response)
+}
+
+// GetBadDays - daftar dina ala (taliwangke, samparwangke, dina sangar, naas) dalam setahun
+func (h *JavaneseCalendarHandler) GetBadDays(w http.ResponseWriter, r *http.Request) {
+	svc, ok := h.serviceFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	vars := mux.Vars(r)
+	yearStr := vars["year"]
+	birthDateStr := vars["birth_date"]
+
+	year, err := strconv.Atoi(yearStr)
+	if err != nil {
+		h.sendErrorResponse(w, http.StatusBadRequest, "Format tahun tidak valid")
+		return
+	}
+
+	if !h.validYear(w, year) {
+		return
+	}
+
+	var birthDate *time.Time
+	message := "Dina ala di tahun " + yearStr
+	if birthDateStr != "" {
+		date, err := svc.ParseDate(birthDateStr)
+		if err != nil {
+			h.sendErrorResponse(w, http.StatusBadRequest, "Format tanggal lahir tidak valid")
+			return
+		}
+		birthDate = &date
+		message = "Dina ala untuk weton " + svc.GetWetonByDate(date) + " di tahun " + yearStr
+	}
+
+	badDays := svc.GetBadDays(year, birthDate)
+
+	response := model.APIResponse{
+		Status:  "success",
+		Message: message,
+		Data: map[string]interface{}{
+			"year":       year,
+			"total_days": len(badDays),
+			"bad_days":   badDays,
+		},
+	}
+
+	h.sendResponse(w, http.StatusOK, response)
+}
+
+// GetHolidays - hari besar Jawa, libur nasional dan cuti bersama dalam setahun
+// atau sebulan (opsional ?type=jawa|nasional|cuti_bersama)
+func (h *JavaneseCalendarHandler) GetHolidays(w http.ResponseWriter, r *http.Request) {
+	svc, ok := h.serviceFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	vars := mux.Vars(r)
+	yearStr := vars["year"]
+	monthStr := vars["month"]
+
+	year, err := strconv.Atoi(yearStr)
+	if err != nil {
+		h.sendErrorResponse(w, http.StatusBadRequest, "Format tahun tidak valid")
+		return
+	}
+
+	if !h.validYear(w, year) {
+		return
+	}
+
+	month := 0
+	if monthStr != "" {
+		month, err = strconv.Atoi(monthStr)
+		if err != nil || month < 1 || month > 12 {
+			h.sendErrorResponse(w, http.StatusBadRequest, "Format bulan tidak valid (1-12)")
+			return
+		}
+	}
+
+	holidayType, err := service.ParseHolidayType(r.URL.Query().Get("type"))
+	if err != nil {
+		h.sendErrorResponse(w, http.StatusBadRequest, "Jenis libur tidak dikenal. Gunakan jawa, nasional atau cuti_bersama")
+		return
+	}
+
+	holidays := svc.GetHolidays(year, month, holidayType)
+
+	message := "Hari libur di tahun " + yearStr
+	if month != 0 {
+		monthNames := []string{"", "Januari", "Februari", "Maret", "April", "Mei", "Juni",
+			"Juli", "Agustus", "September", "Oktober", "November", "Desember"}
+		message = "Hari libur di bulan " + monthNames[month] + " " + yearStr
+	}
+
+	data := map[string]interface{}{
+		"year":           year,
+		"month":          month,
+		"total_holidays": len(holidays),
+		"holidays":       holidays,
+		"national_data":  svc.HolidaySource(year),
+	}
+	if svc.HolidaySource(year) == nil {
+		data["note"] = "Data libur nasional dan cuti bersama belum tersedia untuk tahun ini, hanya hari besar Jawa yang dihitung"
+	}
+
+	response := model.APIResponse{
+		Status:  "success",
+		Message: message,
+		Data:    data,
+	}
+
+	h.sendResponse(w, http.StatusOK, response)
+}
+
+// GetHijri - tanggal Hijriah untuk tanggal Masehi tertentu, dengan
+// perbandingan hasil tabular dan hisab
+func (h *JavaneseCalendarHandler) GetHijri(w http.ResponseWriter, r *http.Request) {
+	svc, ok := h.serviceFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	vars := mux.Vars(r)
+	dateStr := vars["date"]
+
+	date, err := svc.ParseDate(dateStr)
+	if err != nil {
+		h.sendErrorResponse(w, http.StatusBadRequest, "Tanggal tidak valid: "+err.Error())
+		return
+	}
+
+	javaneseDate := svc.ConvertToJavaneseDate(date)
+	if javaneseDate.Hijri == nil {
+		h.sendErrorResponse(w, http.StatusBadRequest, "Tanggal Hijriah hanya tersedia mulai 16 Juli 622 (1 Muharram 1 H)")
+		return
+	}
+
+	day := calendar.FromTime(date)
+
+	response := model.APIResponse{
+		Status:  "success",
+		Message: "Tanggal Hijriah untuk " + dateStr + ": " + javaneseDate.Hijri.Formatted,
+		Data: map[string]interface{}{
+			"gregorian_date": javaneseDate.GregorianDate,
+			"weton":          javaneseDate.Weton,
+			"javanese_date":  formatJavanese(javaneseDate),
+			"hijri":          javaneseDate.Hijri,
+			"tabular":        svc.ConvertToHijri(day, service.HijriTabular),
+			"hisab":          svc.ConvertToHijri(day, service.HijriHisab),
+		},
+	}
+
+	h.sendResponse(w, http.StatusOK, response)
+}
+
+// GetNaga - arah naga dina, naga sasi dan naga tahun untuk tanggal tertentu
+func (h *JavaneseCalendarHandler) GetNaga(w http.ResponseWriter, r *http.Request) {
+	svc, ok := h.serviceFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	vars := mux.Vars(r)
+	dateStr := vars["date"]
+
+	date, err := svc.ParseDate(dateStr)
+	if err != nil {
+		h.sendErrorResponse(w, http.StatusBadRequest, "Tanggal tidak valid: "+err.Error())
+		return
+	}
+
+	response := model.APIResponse{
+		Status:  "success",
+		Message: "Arah naga untuk tanggal " + dateStr,
+		Data:    svc.GetNaga(date),
+	}
+
+	h.sendResponse(w, http.StatusOK, response)
+}
+
+// GetAllWeton - menampilkan semua kemungkinan weton
+func (h *JavaneseCalendarHandler) GetAllWeton(w http.ResponseWriter, r *http.Request) {
+	wetons := h.service.GetAllPossibleWeton()
+
+	response := model.APIResponse{
+		Status:  "success",
+		Message: "Daftar semua kemungkinan weton dalam kalender Jawa",
+		Data: map[string]interface{}{
+			"total_weton": len(wetons),
+			"wetons":      wetons,
+		},
+	}
+
+	h.sendResponse(w, http.StatusOK, response)
+}
+
+// GetWetonStatistics - statistik weton dalam periode tertentu
+func (h *JavaneseCalendarHandler) GetWetonStatistics(w http.ResponseWriter, r *http.Request) {
+	svc, ok := h.serviceFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	vars := mux.Vars(r)
+	startStr := vars["start"]
+	endStr := vars["end"]
+
+	start, err := svc.ParseDate(startStr)
+	if err != nil {
+		h.sendErrorResponse(w, http.StatusBadRequest, "Format tanggal start tidak valid")
+		return
+	}
+
+	end, err := svc.ParseDate(endStr)
+	if err != nil {
+		h.sendErrorResponse(w, http.StatusBadRequest, "Format tanggal end tidak valid")
+		return
+	}
+
+	if start.After(end) {
+		h.sendErrorResponse(w, http.StatusBadRequest, "Tanggal start tidak boleh lebih besar dari end")
+		return
+	}
+
+	// Maksimal 2 tahun untuk menghindari overload
+	maxDays := 730
+	if calendar.FromTime(end).Sub(calendar.FromTime(start)) > maxDays {
+		h.sendErrorResponse(w, http.StatusBadRequest, "Range tanggal maksimal 2 tahun")
+		return
+	}
+
+	dateRange := svc.GetDateRange(start, end)
+
+	// Hitung statistik
+	wetonCount := make(map[string]int)
+	dayCount := make(map[string]int)
 	pasaranCount := make(map[string]int)
 
 	for _, date := range dateRange {
@@ -414,17 +1213,123 @@ func (h *JavaneseCalendarHandler) GetWetonStatistics(w http.ResponseWriter, r *h
 		},
 	}
 
-	h.sendJSONResponse(w, http.StatusOK, response)
+	h.sendResponse(w, http.StatusOK, response)
+}
+
+// normalizeWeton - support strip dan %20, lalu kapitalisasi tiap kata
+// sehingga "selasa-legi" menjadi "Selasa Legi"
+func normalizeWeton(weton string) string {
+	weton = strings.ReplaceAll(weton, "-", " ")   // Convert strip to space
+	weton = strings.ReplaceAll(weton, "%20", " ") // Support legacy URL encoding
+
+	parts := strings.Fields(weton)
+	for i, part := range parts {
+		parts[i] = strings.Title(strings.ToLower(part))
+	}
+	return strings.Join(parts, " ")
+}
+
+// serviceFromRequest menyiapkan service sesuai opsi query ?kurup=, zona
+// waktu (?tz= atau header X-Timezone), sistem kalender (?calendar=) dan
+// metode Hijriah (?hijri=, ?hijri_adjust=), mengirim response error dan
+// mengembalikan false bila opsi tidak valid
+func (h *JavaneseCalendarHandler) serviceFromRequest(w http.ResponseWriter, r *http.Request) (*service.JavaneseCalendarService, bool) {
+	kurup, err := service.ParseKurup(r.URL.Query().Get("kurup"))
+	if err != nil {
+		h.sendErrorResponse(w, http.StatusBadRequest, "Kurup tidak dikenal. Gunakan asapon, aboge atau anenge")
+		return nil, false
+	}
+
+	tz := r.URL.Query().Get("tz")
+	if tz == "" {
+		tz = r.Header.Get("X-Timezone")
+	}
+	system, err := calendar.ParseSystem(r.URL.Query().Get("calendar"))
+	if err != nil {
+		h.sendErrorResponse(w, http.StatusBadRequest, "Sistem kalender tidak dikenal. Gunakan historical, gregorian atau julian")
+		return nil, false
+	}
+
+	hijriMethod, err := service.ParseHijriMethod(r.URL.Query().Get("hijri"))
+	if err != nil {
+		h.sendErrorResponse(w, http.StatusBadRequest, "Metode hijriah tidak dikenal. Gunakan tabular atau hisab")
+		return nil, false
+	}
+
+	hijriAdjustment := 0
+	if adjustStr := r.URL.Query().Get("hijri_adjust"); adjustStr != "" {
+		hijriAdjustment, err = strconv.Atoi(adjustStr)
+		if err != nil || hijriAdjustment < -service.MaxHijriAdjustment || hijriAdjustment > service.MaxHijriAdjustment {
+			h.sendErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Koreksi hijriah harus bilangan bulat antara -%d dan %d", service.MaxHijriAdjustment, service.MaxHijriAdjustment))
+			return nil, false
+		}
+	}
+
+	loc, err := service.ParseTimezone(tz)
+	if err != nil {
+		h.sendErrorResponse(w, http.StatusBadRequest, "Zona waktu tidak valid: "+err.Error()+". Gunakan WIB, WITA, WIT atau nama IANA seperti Asia/Makassar")
+		return nil, false
+	}
+	w.Header().Set("X-Timezone", loc.String())
+
+	svc := h.service.WithKurup(kurup).WithLocation(loc).WithCalendarSystem(system).WithHijri(hijriMethod, hijriAdjustment)
+	return svc, true
+}
+
+// validYear memeriksa batas tahun Masehi dan mengirim response error bila di luar batas
+func (h *JavaneseCalendarHandler) validYear(w http.ResponseWriter, year int) bool {
+	if year < service.MinYear || year > service.MaxYear {
+		h.sendErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Tahun harus antara %d - %d", service.MinYear, service.MaxYear))
+		return false
+	}
+	return true
 }
 
-func (h *JavaneseCalendarHandler) sendJSONResponse(w http.ResponseWriter, statusCode int, data interface{}) {
-	w.Header().Set("Content-Type", "application/json")
-	w.Header().Set("Access-Control-Allow-Origin", "*")
-	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+// sunsetShiftFromRequest membaca opsi ?time=HH:MM beserta ?city= atau
+// ?lat=&lon=. Tanpa ?time= tanggal dikembalikan apa adanya dan shift bernilai nil.
+func (h *JavaneseCalendarHandler) sunsetShiftFromRequest(w http.ResponseWriter, r *http.Request, date time.Time) (time.Time, *model.SunsetShift, bool) {
+	query := r.URL.Query()
+	timeStr := query.Get("time")
+	if timeStr == "" {
+		return date, nil, true
+	}
+
+	clock, err := time.Parse("15:04", timeStr)
+	if err != nil {
+		h.sendErrorResponse(w, http.StatusBadRequest, "Format jam tidak valid. Gunakan HH:MM")
+		return date, nil, false
+	}
+
+	location := service.DefaultLocation
+	latStr, lonStr := query.Get("lat"), query.Get("lon")
+	if city := query.Get("city"); city != "" {
+		found, ok := service.FindCity(city)
+		if !ok {
+			h.sendErrorResponse(w, http.StatusBadRequest, "Kota tidak dikenal: "+city+". Gunakan ?lat=&lon= untuk lokasi lain")
+			return date, nil, false
+		}
+		location = found
+	} else if latStr != "" || lonStr != "" {
+		lat, latErr := strconv.ParseFloat(latStr, 64)
+		lon, lonErr := strconv.ParseFloat(lonStr, 64)
+		if latErr != nil || lonErr != nil {
+			h.sendErrorResponse(w, http.StatusBadRequest, "Koordinat tidak valid. Gunakan ?lat=-7.7956&lon=110.3695")
+			return date, nil, false
+		}
+		location, err = service.NewLocation(lat, lon)
+		if err != nil {
+			h.sendErrorResponse(w, http.StatusBadRequest, "Koordinat tidak valid: "+err.Error())
+			return date, nil, false
+		}
+	}
 
-	w.WriteHeader(statusCode)
-	json.NewEncoder(w).Encode(data)
+	sinceMidnight := time.Duration(clock.Hour())*time.Hour + time.Duration(clock.Minute())*time.Minute
+	effective, shift := service.ApplySunsetBoundary(date, sinceMidnight, location)
+	if effective.Year() > service.MaxYear {
+		h.sendErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("Setelah maghrib tanggal bergeser ke %s, di luar tahun %d - %d", shift.EffectiveDate, service.MinYear, service.MaxYear))
+		return date, nil, false
+	}
+	return effective, shift, true
 }
 
 func (h *JavaneseCalendarHandler) sendErrorResponse(w http.ResponseWriter, statusCode int, message string) {
@@ -433,5 +1338,5 @@ func (h *JavaneseCalendarHandler) sendErrorResponse(w http.ResponseWriter, statu
 		Message: message,
 		Data:    nil,
 	}
-	h.sendJSONResponse(w, statusCode, response)
+	h.sendResponse(w, statusCode, response)
 }
@@ -0,0 +1,88 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/yuxxeun/jakal/internal/routes"
+)
+
+var router = newRouter()
+
+func newRouter() *mux.Router {
+	r := mux.NewRouter()
+	routes.SetupJavaneseCalendarRoutes(r)
+	return r
+}
+
+// serve menjalankan satu request lewat router lengkap
+func serve(method, path, body string, header http.Header) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func decodeJSON(t *testing.T, w *httptest.ResponseRecorder) map[string]interface{} {
+	t.Helper()
+	var response map[string]interface{}
+	body, _ := io.ReadAll(w.Body)
+	if err := json.Unmarshal(body, &response); err != nil {
+		t.Fatalf("respons bukan JSON: %v\n%s", err, body)
+	}
+	return response
+}
+
+// Setiap endpoint yang menerima tahun Masehi menolak tahun di luar 1 - 9999
+func TestYearOutOfRange(t *testing.T) {
+	endpoints := []string{
+		"/api/v1/year/{year}",
+		"/api/v1/month/{year}/1",
+		"/api/v1/mangsa/{year}",
+		"/api/v1/good-days/1990-05-15/{year}",
+		"/api/v1/good-days/nikah/1990-05-15/{year}",
+		"/api/v1/wedding-dates/1990-05-15/1992-01-01/{year}",
+		"/api/v1/bad-days/{year}",
+		"/api/v1/holidays/{year}",
+		"/api/v1/weton/Jumat%20Kliwon/{year}",
+		"/api/v1/wuku/Sinta/{year}",
+		"/api/v1/wewaran/pancawara/Legi/{year}",
+	}
+
+	for _, endpoint := range endpoints {
+		for _, year := range []string{"0", "-5", "10000", "99999999"} {
+			path := strings.Replace(endpoint, "{year}", year, 1)
+			w := serve(http.MethodGet, path, "", nil)
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("GET %s = %d, want 400", path, w.Code)
+				continue
+			}
+			if message := decodeJSON(t, w)["message"]; message != "Tahun harus antara 1 - 9999" {
+				t.Errorf("GET %s: message %q", path, message)
+			}
+		}
+	}
+}
+
+// Kelahiran setelah maghrib 31 Desember 9999 jatuh di tahun 10000
+func TestSunsetShiftOutOfRange(t *testing.T) {
+	for _, endpoint := range []string{"date", "weton", "neptu", "analysis", "slametan"} {
+		path := "/api/v1/" + endpoint + "/9999-12-31?time=23:00"
+		if w := serve(http.MethodGet, path, "", nil); w.Code != http.StatusBadRequest {
+			t.Errorf("GET %s = %d, want 400", path, w.Code)
+		}
+
+		path = "/api/v1/" + endpoint + "/9999-12-31?time=12:00"
+		if w := serve(http.MethodGet, path, "", nil); w.Code != http.StatusOK {
+			t.Errorf("GET %s = %d, want 200", path, w.Code)
+		}
+	}
+}
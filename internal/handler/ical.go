@@ -0,0 +1,266 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/yuxxeun/jakal/internal/model"
+	"github.com/yuxxeun/jakal/internal/service"
+	"github.com/yuxxeun/jakal/pkg/calendar"
+	"github.com/yuxxeun/jakal/pkg/ical"
+)
+
+const icalProdID = "-//Jakal//Javanese Calendar API//ID"
+
+// Feed langganan diperbarui sehari sekali oleh aplikasi kalender
+const icalRefreshInterval = 24 * time.Hour
+
+func (h *JavaneseCalendarHandler) sendICS(w http.ResponseWriter, cal *ical.Calendar, filename string) {
+	cal.ProdID = icalProdID
+	w.Header().Set("Content-Disposition", `inline; filename="`+filename+`"`)
+	h.sendFormatted(w, http.StatusOK, icsFormat, cal)
+}
+
+// dayOf membaca tanggal Gregorian proleptik YYYY-MM-DD dari field gregorian_date
+func dayOf(gregorianDate string) calendar.JDN {
+	date, _ := time.Parse("2006-01-02", gregorianDate)
+	return calendar.FromTime(date)
+}
+
+// dateEvent - satu tanggal Jawa sebagai acara sehari penuh, hari libur ikut
+// disebut di judul agar terlihat di tampilan bulan
+func dateEvent(jd *model.JavaneseDate) ical.Event {
+	summary := jd.Weton + " · " + formatJavanese(jd)
+	categories := []string{"Pasaran " + jd.Pasaran}
+	var names []string
+	for _, holiday := range jd.Holidays {
+		names = append(names, holiday.Name)
+		categories = append(categories, holiday.Type)
+	}
+	if len(names) > 0 {
+		summary += " · " + strings.Join(names, ", ")
+	}
+
+	lines := []string{
+		fmt.Sprintf("Neptu %d", jd.Neptu),
+		"Wuku " + jd.Wuku,
+	}
+	if jd.JavaneseYear != 0 {
+		lines = append(lines, fmt.Sprintf("Tahun %s (%s), windu %s", jd.JavaneseYearName, jd.JavaneseYearType, jd.Windu))
+	} else {
+		lines = append(lines, jd.Note)
+	}
+	if jd.PranataMangsa != nil {
+		lines = append(lines, "Mangsa "+jd.PranataMangsa.Name)
+	}
+	if jd.Hijri != nil {
+		lines = append(lines, jd.Hijri.Formatted)
+	}
+	for _, holiday := range jd.Holidays {
+		if holiday.Description != "" {
+			lines = append(lines, holiday.Name+": "+holiday.Description)
+		}
+	}
+	for _, flag := range jd.BadDays {
+		lines = append(lines, flag.Reason)
+	}
+
+	return ical.Event{
+		UID:         "date-" + jd.GregorianDate + "@jakal",
+		Start:       calendar.JDN(jd.JulianDay),
+		Summary:     summary,
+		Description: strings.Join(lines, "\n"),
+		Categories:  categories,
+	}
+}
+
+func datesCalendar(name string, dates []*model.JavaneseDate) *ical.Calendar {
+	cal := &ical.Calendar{Name: name}
+	for _, jd := range dates {
+		cal.Events = append(cal.Events, dateEvent(jd))
+	}
+	return cal
+}
+
+func wetonCalendar(weton string, dates []model.JavaneseDate) *ical.Calendar {
+	cal := &ical.Calendar{Name: "Weton " + weton}
+	for i := range dates {
+		cal.Events = append(cal.Events, dateEvent(&dates[i]))
+	}
+	return cal
+}
+
+// formatJavanese - tanggal Jawa, atau tahun Saka sebelum 8 Juli 1633
+func formatJavanese(jd *model.JavaneseDate) string {
+	if jd.JavaneseYear == 0 {
+		return fmt.Sprintf("tahun Saka %d", jd.SakaYear)
+	}
+	return fmt.Sprintf("%d %s %d", jd.JavaneseDay, jd.JavaneseMonthName, jd.JavaneseYear)
+}
+
+func goodDaysCalendar(name, purpose string, goodDays []*model.GoodDay) *ical.Calendar {
+	cal := &ical.Calendar{Name: name}
+	for _, day := range goodDays {
+		var lines []string
+		for _, rule := range day.PassedRules {
+			lines = append(lines, rule.Description)
+		}
+		cal.Events = append(cal.Events, ical.Event{
+			UID:         "good-day-" + purpose + "-" + day.GregorianDate + "@jakal",
+			Start:       dayOf(day.GregorianDate),
+			Summary:     "Hari baik " + purpose + " · " + day.Weton + " · " + day.JavaneseDate,
+			Description: strings.Join(lines, "\n"),
+			Categories:  []string{"hari_baik"},
+		})
+	}
+	return cal
+}
+
+// wetonanEvent - acara wetonan yang berulang tiap 35 hari sejak hari lahir
+func wetonanEvent(birthDateStr string, jd *model.JavaneseDate) ical.Event {
+	return ical.Event{
+		UID:         "wetonan-" + birthDateStr + "@jakal",
+		Start:       calendar.JDN(jd.JulianDay),
+		Summary:     "Wetonan " + jd.Weton,
+		Description: fmt.Sprintf("Weton lahir %s, neptu %d", jd.Weton, jd.Neptu),
+		Categories:  []string{"wetonan"},
+		RRule:       fmt.Sprintf("FREQ=DAILY;INTERVAL=%d", calendar.WetonCycle),
+	}
+}
+
+func holidayEvent(holiday *model.HolidayDate) ical.Event {
+	description := holiday.Weton + ", " + holiday.JavaneseDate
+	if holiday.Description != "" {
+		description += "\n" + holiday.Description
+	}
+	return ical.Event{
+		UID:         "holiday-" + holiday.GregorianDate + "-" + strings.ToLower(strings.ReplaceAll(holiday.Name, " ", "-")) + "@jakal",
+		Start:       dayOf(holiday.GregorianDate),
+		Summary:     holiday.Name,
+		Description: description,
+		Categories:  []string{holiday.Type},
+	}
+}
+
+// GetWetonFeed - feed langganan wetonan: satu acara berulang tiap 35 hari
+// sejak tanggal lahir (opsional ?time=HH:MM&city= untuk kelahiran setelah maghrib)
+func (h *JavaneseCalendarHandler) GetWetonFeed(w http.ResponseWriter, r *http.Request) {
+	svc, ok := h.serviceFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	birthDateStr := mux.Vars(r)["birth_date"]
+	birthDate, err := svc.ParseDate(birthDateStr)
+	if err != nil {
+		h.sendErrorResponse(w, http.StatusBadRequest, "Format tanggal lahir tidak valid")
+		return
+	}
+
+	effective, _, ok := h.sunsetShiftFromRequest(w, r, birthDate)
+	if !ok {
+		return
+	}
+
+	jd := svc.ConvertToJavaneseDate(effective)
+	cal := &ical.Calendar{
+		Name:            "Wetonan " + jd.Weton,
+		Description:     "Wetonan untuk kelahiran " + birthDateStr + ", berulang tiap 35 hari",
+		RefreshInterval: icalRefreshInterval,
+		Events:          []ical.Event{wetonanEvent(birthDateStr, jd)},
+	}
+
+	h.sendICS(w, cal, "wetonan-"+birthDateStr+".ics")
+}
+
+// GetSlametanFeed - feed tanggal slametan geblag sampai nyewu
+func (h *JavaneseCalendarHandler) GetSlametanFeed(w http.ResponseWriter, r *http.Request) {
+	svc, ok := h.serviceFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	dateStr := mux.Vars(r)["date_of_death"]
+	deathDate, err := svc.ParseDate(dateStr)
+	if err != nil {
+		h.sendErrorResponse(w, http.StatusBadRequest, "Format tanggal wafat tidak valid")
+		return
+	}
+
+	_, shift, ok := h.sunsetShiftFromRequest(w, r, deathDate)
+	if !ok {
+		return
+	}
+
+	slametan, err := svc.GetSlametan(deathDate, shift)
+	if err != nil {
+		h.sendErrorResponse(w, http.StatusBadRequest, "Tanggal slametan tidak dapat dihitung: "+err.Error())
+		return
+	}
+	cal := &ical.Calendar{
+		Name:            "Slametan " + dateStr,
+		Description:     "Slametan untuk wafat " + dateStr + " (geblag " + slametan.GeblagWeton + ")",
+		RefreshInterval: icalRefreshInterval,
+	}
+	for _, commemoration := range slametan.Commemorations {
+		description := fmt.Sprintf("Hari ke-%d sejak geblag, %s", commemoration.Day, commemoration.JavaneseDate)
+		if commemoration.Rumus != "" {
+			description += "\nRumus " + commemoration.Rumus + " (" + commemoration.RumusWeton + ")"
+		}
+		cal.Events = append(cal.Events, ical.Event{
+			UID:         "slametan-" + dateStr + "-" + strconv.Itoa(commemoration.Day) + "@jakal",
+			Start:       dayOf(commemoration.GregorianDate),
+			Summary:     commemoration.Name + " · " + commemoration.Weton,
+			Description: description,
+			Categories:  []string{"slametan"},
+		})
+	}
+
+	h.sendICS(w, cal, "slametan-"+dateStr+".ics")
+}
+
+// GetHolidaysFeed - feed hari libur untuk tahun tertentu, atau tahun ini dan
+// tahun depan bila tahun tidak disebut (opsional ?type=)
+func (h *JavaneseCalendarHandler) GetHolidaysFeed(w http.ResponseWriter, r *http.Request) {
+	svc, ok := h.serviceFromRequest(w, r)
+	if !ok {
+		return
+	}
+
+	holidayType, err := service.ParseHolidayType(r.URL.Query().Get("type"))
+	if err != nil {
+		h.sendErrorResponse(w, http.StatusBadRequest, "Jenis libur tidak dikenal. Gunakan jawa, nasional atau cuti_bersama")
+		return
+	}
+
+	years := []int{svc.Now().Year(), svc.Now().Year() + 1}
+	filename := "libur.ics"
+	if yearStr := mux.Vars(r)["year"]; yearStr != "" {
+		year, err := strconv.Atoi(yearStr)
+		if err != nil {
+			h.sendErrorResponse(w, http.StatusBadRequest, "Format tahun tidak valid")
+			return
+		}
+		if !h.validYear(w, year) {
+			return
+		}
+		years = []int{year}
+		filename = "libur-" + yearStr + ".ics"
+	}
+
+	cal := &ical.Calendar{
+		Name:            "Hari Libur dan Hari Besar Jawa",
+		RefreshInterval: icalRefreshInterval,
+	}
+	for _, year := range years {
+		for _, holiday := range svc.GetHolidays(year, 0, holidayType) {
+			cal.Events = append(cal.Events, holidayEvent(holiday))
+		}
+	}
+
+	h.sendICS(w, cal, filename)
+}
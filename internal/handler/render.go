@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/yuxxeun/jakal/internal/model"
+	"github.com/yuxxeun/jakal/pkg/ical"
+	"github.com/yuxxeun/jakal/pkg/render"
+)
+
+// formats - format respons yang bisa dipilih lewat header Accept atau
+// ?format=. Format baru cukup didaftarkan di sini tanpa mengubah handler.
+var formats = newFormatRegistry()
+
+var errFormatUnsupported = errors.New("format tidak didukung untuk data ini")
+
+// icsFormat hanya bisa menulis *ical.Calendar; endpoint yang mendukung .ics
+// menyusun kalendernya sendiri lalu memanggil sendICS
+var icsFormat = &render.Format{
+	Name:        "ics",
+	ContentType: ical.ContentType,
+	Encode: func(w io.Writer, v interface{}) error {
+		cal, ok := v.(*ical.Calendar)
+		if !ok {
+			return errFormatUnsupported
+		}
+		return cal.Encode(w)
+	},
+}
+
+func newFormatRegistry() *render.Registry {
+	registry := render.NewRegistry()
+	registry.Register(render.JSON)
+	registry.Register(render.CSV)
+	registry.Register(render.XML)
+	registry.Register(render.MessagePack)
+	registry.Register(textFormat)
+	registry.Register(icsFormat)
+	return registry
+}
+
+// formatWriter membawa format hasil negosiasi sampai ke sendResponse
+type formatWriter struct {
+	http.ResponseWriter
+	format *render.Format
+}
+
+// Negotiate - middleware yang memilih format respons sekali per request
+func (h *JavaneseCalendarHandler) Negotiate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept")
+
+		format, err := formats.Negotiate(r.URL.Query().Get("format"), r.Header.Get("Accept"))
+		if err != nil {
+			h.sendErrorResponse(w, http.StatusBadRequest, "Format tidak dikenal. Gunakan salah satu: "+strings.Join(formats.Names(), ", "))
+			return
+		}
+
+		next.ServeHTTP(&formatWriter{ResponseWriter: w, format: format}, r)
+	})
+}
+
+// formatOf mengembalikan format yang dipilih Negotiate, atau JSON bila
+// request tidak melewati middleware tersebut
+func formatOf(w http.ResponseWriter) *render.Format {
+	if fw, ok := w.(*formatWriter); ok {
+		return fw.format
+	}
+	return formats.Default()
+}
+
+// wantsICS - output .ics diminta lewat ?format=ics atau header Accept text/calendar
+func wantsICS(w http.ResponseWriter) bool {
+	return formatOf(w) == icsFormat
+}
+
+func (h *JavaneseCalendarHandler) sendResponse(w http.ResponseWriter, statusCode int, data interface{}) {
+	h.sendFormatted(w, statusCode, formatOf(w), data)
+}
+
+func (h *JavaneseCalendarHandler) sendFormatted(w http.ResponseWriter, statusCode int, format *render.Format, data interface{}) {
+	var body bytes.Buffer
+	if err := format.Encode(&body, data); err != nil {
+		// Data tidak bisa ditulis dalam format yang diminta, misalnya ics pada
+		// endpoint yang bukan kalender; kirim penjelasan dalam format bawaan
+		requested := format.Name
+		format = formats.Default()
+		statusCode = http.StatusNotAcceptable
+		body.Reset()
+		format.Encode(&body, model.APIResponse{
+			Status:  "error",
+			Message: "Format " + requested + " tidak tersedia untuk endpoint ini",
+		})
+	}
+
+	w.Header().Set("Content-Type", format.ContentType)
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
+	w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Timezone")
+
+	w.WriteHeader(statusCode)
+	w.Write(body.Bytes())
+}
@@ -0,0 +1,180 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/yuxxeun/jakal/internal/model"
+	"github.com/yuxxeun/jakal/pkg/calendar"
+	"github.com/yuxxeun/jakal/pkg/render"
+)
+
+// Lebar satu kolom hari pada kisi bulan
+const gridCellWidth = 11
+
+// textFormat menampilkan data /month sebagai kisi ala cal; data lain
+// tetap ditulis oleh render.Text
+var textFormat = &render.Format{
+	Name:        render.Text.Name,
+	ContentType: render.Text.ContentType,
+	MediaTypes:  render.Text.MediaTypes,
+	Encode: func(w io.Writer, v interface{}) error {
+		if response, ok := v.(model.APIResponse); ok {
+			if month, ok := response.Data.(*model.MonthData); ok {
+				_, err := io.WriteString(w, monthGrid(month))
+				return err
+			}
+		}
+		return render.Text.Encode(w, v)
+	},
+}
+
+// terminalClient - curl dan wget tanpa pilihan format eksplisit mendapat
+// keluaran teks, karena JSON satu baris sulit dibaca di terminal
+func terminalClient(r *http.Request) bool {
+	if r.URL.Query().Get("format") != "" {
+		return false
+	}
+	if accept := r.Header.Get("Accept"); accept != "" && accept != "*/*" {
+		return false
+	}
+	agent := strings.ToLower(r.UserAgent())
+	return strings.HasPrefix(agent, "curl/") || strings.HasPrefix(agent, "wget/")
+}
+
+// monthGrid menyusun kisi satu bulan: tiap hari berisi tanggal Masehi,
+// pasaran dan tanggal Jawa. Tanggal 1 bulan Jawa ditandai [1] dan hari libur
+// ditandai *, keduanya dijelaskan di bawah kisi.
+func monthGrid(month *model.MonthData) string {
+	monthNames := []string{"", "Januari", "Februari", "Maret", "April", "Mei", "Juni",
+		"Juli", "Agustus", "September", "Oktober", "November", "Desember"}
+	width := gridCellWidth * len(calendar.DayNames)
+
+	var b strings.Builder
+	if len(month.Dates) == 0 {
+		return ""
+	}
+	first, last := month.Dates[0], month.Dates[len(month.Dates)-1]
+
+	writeCentered(&b, width, fmt.Sprintf("%s %d", monthNames[month.Month], month.Year))
+	writeCentered(&b, width, javaneseSpan(first, last))
+	b.WriteString("\n")
+
+	var header strings.Builder
+	for _, name := range calendar.DayNames {
+		fmt.Fprintf(&header, "%-*s", gridCellWidth, name)
+	}
+	b.WriteString(strings.TrimRight(header.String(), " ") + "\n")
+	b.WriteString(strings.Repeat("-", width) + "\n")
+
+	// Tanggal dikelompokkan per minggu menurut hari dalam data, sehingga
+	// bulan yang terpotong reformasi Gregorian (Oktober 1582) tetap tersusun benar
+	var weeks [][7]*model.JavaneseDate
+	previous := len(calendar.DayNames)
+	for _, date := range month.Dates {
+		column := date.DayOfWeek - 1
+		if column <= previous {
+			weeks = append(weeks, [7]*model.JavaneseDate{})
+		}
+		weeks[len(weeks)-1][column] = date
+		previous = column
+	}
+
+	var newMonths, holidays []string
+	for _, week := range weeks {
+		var gregorian, pasaran, javanese strings.Builder
+		for _, date := range week {
+			if date == nil {
+				for _, line := range []*strings.Builder{&gregorian, &pasaran, &javanese} {
+					line.WriteString(strings.Repeat(" ", gridCellWidth))
+				}
+				continue
+			}
+
+			day := calendarDay(date)
+			label := fmt.Sprintf("%d %s", day, monthNames[month.Month])
+			cell := fmt.Sprintf("%d", day)
+			if len(date.Holidays) > 0 {
+				cell += "*"
+				for _, holiday := range date.Holidays {
+					holidays = append(holidays, label+" - "+holiday.Name)
+				}
+			}
+
+			// Sebelum 8 Juli 1633 tanggal Jawa belum ada dan barisnya dibiarkan kosong
+			javaneseDay := ""
+			if date.JavaneseDay != 0 {
+				javaneseDay = fmt.Sprintf("%d", date.JavaneseDay)
+			}
+			if date.JavaneseDay == 1 {
+				javaneseDay = "[1]"
+				newMonths = append(newMonths, fmt.Sprintf("%s = 1 %s %d", label, date.JavaneseMonthName, date.JavaneseYear))
+			}
+
+			fmt.Fprintf(&gregorian, "%-*s", gridCellWidth, cell)
+			fmt.Fprintf(&pasaran, "%-*s", gridCellWidth, date.Pasaran)
+			fmt.Fprintf(&javanese, "%-*s", gridCellWidth, javaneseDay)
+		}
+		for _, line := range []*strings.Builder{&gregorian, &pasaran, &javanese} {
+			b.WriteString(strings.TrimRight(line.String(), " ") + "\n")
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("Baris: tanggal Masehi, pasaran, tanggal Jawa\n")
+	if first.Note != "" {
+		b.WriteString(first.Note + "\n")
+	}
+	for _, line := range newMonths {
+		b.WriteString("[1] " + line + "\n")
+	}
+	for _, line := range holidays {
+		b.WriteString("*   " + line + "\n")
+	}
+	return b.String()
+}
+
+// calendarDay - tanggal dalam sistem kalender bulan yang diminta: tanggal
+// Julian bila ada, selain itu tanggal Gregorian
+func calendarDay(date *model.JavaneseDate) int {
+	value := date.GregorianDate
+	if date.JulianDate != "" {
+		value = date.JulianDate
+	}
+	day, _ := strconv.Atoi(value[len(value)-2:])
+	return day
+}
+
+// javaneseSpan - bulan Jawa yang dicakup satu bulan Masehi, misalnya
+// "Sapar - Mulud 1959 (Dal), kurup Asapon". Sebelum 8 Juli 1633 yang
+// ditulis adalah tahun Saka.
+func javaneseSpan(first, last *model.JavaneseDate) string {
+	if last.JavaneseYear == 0 {
+		if first.SakaYear != last.SakaYear {
+			return fmt.Sprintf("Tahun Saka %d - %d", first.SakaYear, last.SakaYear)
+		}
+		return fmt.Sprintf("Tahun Saka %d", first.SakaYear)
+	}
+	if first.JavaneseYear == 0 {
+		return fmt.Sprintf("Tahun Saka %d - %s %d (%s), kurup %s", first.SakaYear, last.JavaneseMonthName, last.JavaneseYear, last.JavaneseYearName, last.Kurup)
+	}
+
+	span := fmt.Sprintf("%s %d", first.JavaneseMonthName, first.JavaneseYear)
+	switch {
+	case first.JavaneseYear != last.JavaneseYear:
+		span = fmt.Sprintf("%s %d - %s %d", first.JavaneseMonthName, first.JavaneseYear, last.JavaneseMonthName, last.JavaneseYear)
+	case first.JavaneseMonth != last.JavaneseMonth:
+		span = fmt.Sprintf("%s - %s %d", first.JavaneseMonthName, last.JavaneseMonthName, last.JavaneseYear)
+	}
+	return fmt.Sprintf("%s (%s), kurup %s", span, last.JavaneseYearName, last.Kurup)
+}
+
+func writeCentered(b *strings.Builder, width int, text string) {
+	if padding := (width - len(text)) / 2; padding > 0 {
+		b.WriteString(strings.Repeat(" ", padding))
+	}
+	b.WriteString(text + "\n")
+}
@@ -0,0 +1,210 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Namespace XML yang dipakai WebDAV dan CalDAV
+const (
+	davNS            = "DAV:"
+	caldavNS         = "urn:ietf:params:xml:ns:caldav"
+	calendarServerNS = "http://calendarserver.org/ns/"
+)
+
+var davPrefixes = map[string]string{
+	davNS:            "d",
+	caldavNS:         "c",
+	calendarServerNS: "cs",
+}
+
+// davProp - satu properti beserta isi XML-nya yang sudah di-escape
+type davProp struct {
+	name  xml.Name
+	value string
+}
+
+// davResponse - satu elemen response dalam multistatus. Bila status diisi,
+// response hanya berisi status tanpa propstat (misalnya 404 pada multiget).
+type davResponse struct {
+	href    string
+	status  int
+	found   []davProp
+	missing []xml.Name
+}
+
+// davPropNames menampung daftar nama properti di dalam <prop>
+type davPropNames struct {
+	Names []struct {
+		XMLName xml.Name
+	} `xml:",any"`
+}
+
+type davPropfind struct {
+	AllProp  *struct{}     `xml:"DAV: allprop"`
+	PropName *struct{}     `xml:"DAV: propname"`
+	Prop     *davPropNames `xml:"DAV: prop"`
+}
+
+type davReport struct {
+	XMLName xml.Name
+	AllProp *struct{}     `xml:"DAV: allprop"`
+	Prop    *davPropNames `xml:"DAV: prop"`
+	Filter  *struct {
+		CompFilter caldavCompFilter `xml:"urn:ietf:params:xml:ns:caldav comp-filter"`
+	} `xml:"urn:ietf:params:xml:ns:caldav filter"`
+	Hrefs []string `xml:"DAV: href"`
+}
+
+type caldavCompFilter struct {
+	Name      string `xml:"name,attr"`
+	TimeRange *struct {
+		Start string `xml:"start,attr"`
+		End   string `xml:"end,attr"`
+	} `xml:"urn:ietf:params:xml:ns:caldav time-range"`
+	CompFilters []caldavCompFilter `xml:"urn:ietf:params:xml:ns:caldav comp-filter"`
+}
+
+// requestedProps mengembalikan nama properti yang diminta, atau nil bila
+// semua properti diminta (allprop, propname atau body kosong). Pada propname
+// nilainya dikosongkan dengan propNamesOnly.
+func (p *davPropNames) requestedProps() []xml.Name {
+	if p == nil {
+		return nil
+	}
+	names := make([]xml.Name, 0, len(p.Names))
+	for _, name := range p.Names {
+		names = append(names, name.XMLName)
+	}
+	return names
+}
+
+// readDAVBody membaca body XML; body kosong dibiarkan sebagai nilai nol
+func readDAVBody(r *http.Request, v interface{}) error {
+	body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+	return xml.Unmarshal(body, v)
+}
+
+// selectProps memisahkan properti yang tersedia dan yang tidak dikenal.
+// Tanpa daftar nama, semua properti yang tersedia dikembalikan.
+func selectProps(available []davProp, requested []xml.Name) (found []davProp, missing []xml.Name) {
+	if requested == nil {
+		return available, nil
+	}
+	for _, name := range requested {
+		matched := false
+		for _, prop := range available {
+			if prop.name == name {
+				found = append(found, prop)
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			missing = append(missing, name)
+		}
+	}
+	return found, missing
+}
+
+// propNamesOnly - jawaban PROPFIND <propname/>: hanya nama properti
+// tanpa isinya (RFC 4918 9.1)
+func propNamesOnly(props []davProp) []davProp {
+	names := make([]davProp, 0, len(props))
+	for _, prop := range props {
+		names = append(names, davProp{name: prop.name})
+	}
+	return names
+}
+
+// writeDAVError menjawab dengan body DAV:error berisi precondition yang
+// dilanggar (RFC 4918 16), disertai penjelasan untuk dibaca manusia
+func writeDAVError(w http.ResponseWriter, status int, precondition xml.Name, message string) {
+	var buf bytes.Buffer
+	buf.WriteString(`<?xml version="1.0" encoding="utf-8"?>` + "\n")
+	buf.WriteString(`<d:error xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav" xmlns:cs="http://calendarserver.org/ns/">`)
+	writeDAVElement(&buf, precondition, "")
+	writeDAVElement(&buf, xml.Name{Space: davNS, Local: "responsedescription"}, xmlEscape(message))
+	buf.WriteString("</d:error>\n")
+
+	w.Header().Set("Content-Type", `application/xml; charset=utf-8`)
+	w.WriteHeader(status)
+	w.Write(buf.Bytes())
+}
+
+func writeMultistatus(w http.ResponseWriter, responses []davResponse) {
+	var buf bytes.Buffer
+	buf.WriteString(`<?xml version="1.0" encoding="utf-8"?>` + "\n")
+	buf.WriteString(`<d:multistatus xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav" xmlns:cs="http://calendarserver.org/ns/">`)
+	for _, response := range responses {
+		buf.WriteString("<d:response><d:href>")
+		xml.EscapeText(&buf, []byte(response.href))
+		buf.WriteString("</d:href>")
+		if response.status != 0 {
+			fmt.Fprintf(&buf, "<d:status>%s</d:status>", statusLine(response.status))
+		}
+		if len(response.found) > 0 {
+			buf.WriteString("<d:propstat><d:prop>")
+			for _, prop := range response.found {
+				writeDAVElement(&buf, prop.name, prop.value)
+			}
+			fmt.Fprintf(&buf, "</d:prop><d:status>%s</d:status></d:propstat>", statusLine(http.StatusOK))
+		}
+		if len(response.missing) > 0 {
+			buf.WriteString("<d:propstat><d:prop>")
+			for _, name := range response.missing {
+				writeDAVElement(&buf, name, "")
+			}
+			fmt.Fprintf(&buf, "</d:prop><d:status>%s</d:status></d:propstat>", statusLine(http.StatusNotFound))
+		}
+		buf.WriteString("</d:response>")
+	}
+	buf.WriteString("</d:multistatus>\n")
+
+	w.Header().Set("Content-Type", `application/xml; charset=utf-8`)
+	w.WriteHeader(http.StatusMultiStatus)
+	w.Write(buf.Bytes())
+}
+
+// writeDAVElement menulis satu elemen; namespace di luar DAV, CalDAV dan
+// CalendarServer dideklarasikan langsung di elemennya
+func writeDAVElement(buf *bytes.Buffer, name xml.Name, value string) {
+	tag := name.Local
+	declaration := ""
+	if prefix, ok := davPrefixes[name.Space]; ok {
+		tag = prefix + ":" + name.Local
+	} else if name.Space != "" {
+		tag = "x:" + name.Local
+		declaration = ` xmlns:x="` + xmlEscape(name.Space) + `"`
+	}
+
+	if value == "" {
+		buf.WriteString("<" + tag + declaration + "/>")
+		return
+	}
+	buf.WriteString("<" + tag + declaration + ">" + value + "</" + tag + ">")
+}
+
+func statusLine(code int) string {
+	return fmt.Sprintf("HTTP/1.1 %d %s", code, http.StatusText(code))
+}
+
+func xmlEscape(text string) string {
+	var buf strings.Builder
+	xml.EscapeText(&buf, []byte(text))
+	return buf.String()
+}
+
+func davHref(href string) string {
+	return "<d:href>" + xmlEscape(href) + "</d:href>"
+}
@@ -3,14 +3,115 @@ package model
 import "time"
 
 type JavaneseDate struct {
+	GregorianDate     string         `json:"gregorian_date"`
+	Day               string         `json:"day"`
+	Pasaran           string         `json:"pasaran"`
+	Weton             string         `json:"weton"`
+	JavaneseDay       int            `json:"javanese_day,omitempty"`
+	JavaneseMonth     int            `json:"javanese_month,omitempty"`
+	JavaneseMonthName string         `json:"javanese_month_name,omitempty"`
+	JavaneseYear      int            `json:"javanese_year,omitempty"`
+	JavaneseYearName  string         `json:"javanese_year_name,omitempty"`
+	JavaneseYearType  string         `json:"javanese_year_type,omitempty"`
+	JavaneseYearNeptu int            `json:"javanese_year_neptu,omitempty"`
+	Windu             string         `json:"windu,omitempty"`
+	Kurup             string         `json:"kurup,omitempty"`
+	Wuku              string         `json:"wuku"`
+	WukuIndex         int            `json:"wuku_index"`
+	WukuNeptu         int            `json:"wuku_neptu"`
+	WukuDeity         string         `json:"wuku_deity"`
+	WukuTree          string         `json:"wuku_tree"`
+	WukuBird          string         `json:"wuku_bird"`
+	PranataMangsa     *PranataMangsa `json:"pranata_mangsa"`
+	Wewaran           []*Wewaran     `json:"wewaran"`
+	BadDays           []*DayFlag     `json:"bad_days"`
+	Holidays          []*Holiday     `json:"holidays,omitempty"`
+	DayOfWeek         int            `json:"day_of_week"`
+	PasaranIndex      int            `json:"pasaran_index"`
+	Neptu             int            `json:"neptu"`
+	Timezone          string         `json:"timezone"`
+	JulianDay         int            `json:"julian_day"`
+	JulianDate        string         `json:"julian_date,omitempty"`
+	SakaYear          int            `json:"saka_year,omitempty"`
+	Hijri             *HijriDate     `json:"hijri,omitempty"`
+	SunsetShift       *SunsetShift   `json:"sunset_shift,omitempty"`
+	Note              string         `json:"note,omitempty"`
+}
+
+// Wewaran untuk posisi tanggal dalam satu siklus hari (dwiwara ... dasawara)
+type Wewaran struct {
+	Cycle    string `json:"cycle"`
+	Alias    string `json:"alias,omitempty"`
+	Name     string `json:"name"`
+	Position int    `json:"position"`
+	Urip     int    `json:"urip"`
+}
+
+// DayFlag untuk penanda dina ala beserta alasannya
+type DayFlag struct {
+	Rule   string `json:"rule"`
+	Reason string `json:"reason"`
+}
+
+// Holiday untuk hari besar Jawa, libur nasional atau cuti bersama pada satu tanggal
+type Holiday struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Description string `json:"description,omitempty"`
+}
+
+// HolidayDate untuk satu hari libur dalam daftar libur setahun
+type HolidayDate struct {
 	GregorianDate string `json:"gregorian_date"`
-	Day           string `json:"day"`
-	Pasaran       string `json:"pasaran"`
 	Weton         string `json:"weton"`
-	JavaneseYear  int    `json:"javanese_year"`
-	DayOfWeek     int    `json:"day_of_week"`
-	PasaranIndex  int    `json:"pasaran_index"`
-	Neptu         int    `json:"neptu"`
+	JavaneseDate  string `json:"javanese_date"`
+	Name          string `json:"name"`
+	Type          string `json:"type"`
+	Description   string `json:"description,omitempty"`
+}
+
+// HolidaySource menjelaskan versi berkas data libur nasional yang dipakai
+type HolidaySource struct {
+	Year    int    `json:"year"`
+	Version string `json:"version"`
+	Source  string `json:"source"`
+	Note    string `json:"note,omitempty"`
+}
+
+// BadDay untuk satu tanggal yang terkena aturan dina ala
+type BadDay struct {
+	GregorianDate string     `json:"gregorian_date"`
+	Weton         string     `json:"weton"`
+	JavaneseDate  string     `json:"javanese_date"`
+	Flags         []*DayFlag `json:"flags"`
+}
+
+// Naga untuk arah naga dina, naga sasi dan naga tahun pada satu tanggal
+type Naga struct {
+	GregorianDate       string          `json:"gregorian_date"`
+	Weton               string          `json:"weton"`
+	JavaneseDate        string          `json:"javanese_date"`
+	Note                string          `json:"note,omitempty"`
+	Positions           []*NagaPosition `json:"positions"`
+	ForbiddenDirections []string        `json:"forbidden_directions"`
+	FavorableDirections []string        `json:"favorable_directions"`
+}
+
+type NagaPosition struct {
+	Kind      string `json:"kind"`
+	Direction string `json:"direction"`
+	Basis     string `json:"basis"`
+}
+
+// PranataMangsa untuk musim dalam kalender pertanian Jawa
+type PranataMangsa struct {
+	Number int    `json:"number"`
+	Name   string `json:"name"`
+	Start  string `json:"start"`
+	End    string `json:"end"`
+	Length int    `json:"length"`
+	Candra string `json:"candra"`
+	Watak  string `json:"watak"`
 }
 
 type APIResponse struct {
@@ -33,6 +134,42 @@ type MonthData struct {
 	Dates     []*JavaneseDate `json:"dates"`
 }
 
+// JavaneseYearData untuk data satu tahun Jawa beserta daftar bulannya
+type JavaneseYearData struct {
+	Year      int                  `json:"year"`
+	YearName  string               `json:"year_name"`
+	YearType  string               `json:"year_type"`
+	YearNeptu int                  `json:"year_neptu"`
+	Windu     string               `json:"windu"`
+	Kurup     string               `json:"kurup"`
+	TotalDays int                  `json:"total_days"`
+	Months    []*JavaneseMonthInfo `json:"months"`
+}
+
+type JavaneseMonthInfo struct {
+	Month          int    `json:"month"`
+	Name           string `json:"name"`
+	Length         int    `json:"length"`
+	GregorianStart string `json:"gregorian_start"`
+	GregorianEnd   string `json:"gregorian_end"`
+}
+
+// KurupComparison untuk membandingkan dua petungan kurup
+type KurupComparison struct {
+	Kurup1        string             `json:"kurup1"`
+	Kurup2        string             `json:"kurup2"`
+	TotalDays     int                `json:"total_days"`
+	DifferentDays int                `json:"different_days"`
+	Differences   []*KurupDifference `json:"differences"`
+}
+
+type KurupDifference struct {
+	GregorianDate string `json:"gregorian_date"`
+	Weton         string `json:"weton"`
+	Date1         string `json:"date1"`
+	Date2         string `json:"date2"`
+}
+
 type YearStatistics struct {
 	DayCount     map[string]int `json:"day_count"`
 	PasaranCount map[string]int `json:"pasaran_count"`
@@ -41,13 +178,23 @@ type YearStatistics struct {
 
 // WetonCompatibility untuk menghitung kecocokan weton
 type WetonCompatibility struct {
-	Weton1        string `json:"weton1"`
-	Weton2        string `json:"weton2"`
-	Neptu1        int    `json:"neptu1"`
-	Neptu2        int    `json:"neptu2"`
-	TotalNeptu    int    `json:"total_neptu"`
-	Compatibility string `json:"compatibility"`
-	Description   string `json:"description"`
+	Weton1        string                 `json:"weton1"`
+	Weton2        string                 `json:"weton2"`
+	Neptu1        int                    `json:"neptu1"`
+	Neptu2        int                    `json:"neptu2"`
+	TotalNeptu    int                    `json:"total_neptu"`
+	Compatibility string                 `json:"compatibility"`
+	Description   string                 `json:"description"`
+	Methods       []*CompatibilityMethod `json:"methods"`
+}
+
+// CompatibilityMethod untuk hasil satu petungan kecocokan weton
+type CompatibilityMethod struct {
+	Method      string `json:"method"`
+	Remainders  []int  `json:"remainders"`
+	Verdict     string `json:"verdict"`
+	Description string `json:"description"`
+	Favorable   bool   `json:"favorable"`
 }
 
 // GoodDaysRequest untuk request hari baik
@@ -58,10 +205,93 @@ type GoodDaysRequest struct {
 
 // GoodDaysResponse untuk response hari baik
 type GoodDaysResponse struct {
-	BirthWeton string      `json:"birth_weton"`
-	Year       int         `json:"year"`
-	GoodDays   []time.Time `json:"good_days"`
-	TotalDays  int         `json:"total_days"`
+	BirthWeton string     `json:"birth_weton"`
+	Purpose    string     `json:"purpose"`
+	Year       int        `json:"year"`
+	GoodDays   []*GoodDay `json:"good_days"`
+	TotalDays  int        `json:"total_days"`
+}
+
+// GoodDay untuk satu hari baik beserta aturan yang dipenuhi
+type GoodDay struct {
+	GregorianDate string        `json:"gregorian_date"`
+	Weton         string        `json:"weton"`
+	JavaneseDate  string        `json:"javanese_date"`
+	PassedRules   []*PassedRule `json:"passed_rules"`
+}
+
+type PassedRule struct {
+	Rule        string `json:"rule"`
+	Description string `json:"description"`
+}
+
+// WeddingDatesResponse untuk rekomendasi tanggal pernikahan dua calon
+type WeddingDatesResponse struct {
+	BrideWeton    string              `json:"bride_weton"`
+	GroomWeton    string              `json:"groom_weton"`
+	Year          int                 `json:"year"`
+	Compatibility *WetonCompatibility `json:"compatibility"`
+	TotalDates    int                 `json:"total_dates"`
+	Dates         []*WeddingDate      `json:"dates"`
+}
+
+type WeddingDate struct {
+	Rank          int            `json:"rank"`
+	GregorianDate string         `json:"gregorian_date"`
+	Weton         string         `json:"weton"`
+	JavaneseDate  string         `json:"javanese_date"`
+	Score         int            `json:"score"`
+	Reasons       []*ScoreReason `json:"reasons"`
+}
+
+type ScoreReason struct {
+	Points int    `json:"points"`
+	Reason string `json:"reason"`
+}
+
+// HijriDate - tanggal Hijriah beserta metode perhitungannya
+type HijriDate struct {
+	Day        int    `json:"day"`
+	Month      int    `json:"month"`
+	MonthName  string `json:"month_name"`
+	Year       int    `json:"year"`
+	Formatted  string `json:"formatted"`
+	Method     string `json:"method"`
+	Adjustment int    `json:"adjustment,omitempty"`
+}
+
+// SunsetShift menjelaskan pergantian hari Jawa saat maghrib
+type SunsetShift struct {
+	Time          string  `json:"time"`
+	Sunset        string  `json:"sunset"`
+	Location      string  `json:"location"`
+	Latitude      float64 `json:"latitude"`
+	Longitude     float64 `json:"longitude"`
+	UTCOffset     int     `json:"utc_offset"`
+	Applied       bool    `json:"applied"`
+	EffectiveDate string  `json:"effective_date"`
+}
+
+// SlametanResponse untuk tanggal-tanggal peringatan kematian
+type SlametanResponse struct {
+	DateOfDeath    string       `json:"date_of_death"`
+	AfterSunset    bool         `json:"after_sunset"`
+	SunsetShift    *SunsetShift `json:"sunset_shift,omitempty"`
+	GeblagDate     string       `json:"geblag_date"`
+	GeblagWeton    string       `json:"geblag_weton"`
+	Commemorations []*Slametan  `json:"commemorations"`
+	Note           string       `json:"note,omitempty"`
+}
+
+type Slametan struct {
+	Name          string `json:"name"`
+	Day           int    `json:"day"`
+	GregorianDate string `json:"gregorian_date"`
+	Weton         string `json:"weton"`
+	JavaneseDate  string `json:"javanese_date"`
+	Rumus         string `json:"rumus,omitempty"`
+	RumusWeton    string `json:"rumus_weton,omitempty"`
+	RumusMatches  *bool  `json:"rumus_matches,omitempty"`
 }
 
 // Primbon data untuk perhitungan tradisional
@@ -13,26 +13,56 @@ func SetupJavaneseCalendarRoutes(router *mux.Router) {
 	javaneseService := service.NewJavaneseCalendarService()
 	javaneseHandler := handler.NewJavaneseCalendarHandler(javaneseService)
 
+	// CalDAV read-only di luar /api/v1 agar bisa ditemukan lewat /.well-known/caldav
+	router.HandleFunc("/.well-known/caldav", javaneseHandler.CalDAVWellKnown)
+	router.HandleFunc("/caldav", javaneseHandler.CalDAV)
+	router.PathPrefix(handler.CalDAVPrefix).HandlerFunc(javaneseHandler.CalDAV)
+
 	api := router.PathPrefix("/api/v1").Subrouter()
+	api.Use(javaneseHandler.Negotiate)
 
 	api.HandleFunc("/today", javaneseHandler.GetToday).Methods("GET")
 	api.HandleFunc("/date/{date}", javaneseHandler.GetByDate).Methods("GET")
 	api.HandleFunc("/range/{start}/{end}", javaneseHandler.GetDateRange).Methods("GET")
 	api.HandleFunc("/year/{year}", javaneseHandler.GetByYear).Methods("GET")
 	api.HandleFunc("/month/{year}/{month}", javaneseHandler.GetByMonth).Methods("GET")
+	api.HandleFunc("/javanese-year/{year}", javaneseHandler.GetJavaneseYear).Methods("GET")
+	api.HandleFunc("/from-javanese/{year}/{month}/{day}", javaneseHandler.GetFromJavanese).Methods("GET")
+	api.HandleFunc("/mangsa/{year}", javaneseHandler.GetPranataMangsa).Methods("GET")
+	api.HandleFunc("/kurup/{kurup1}/{kurup2}/{start}/{end}", javaneseHandler.CompareKurup).Methods("GET")
 
 	api.HandleFunc("/weton/{date}", javaneseHandler.GetWeton).Methods("GET")
 	api.HandleFunc("/neptu/{date}", javaneseHandler.GetNeptu).Methods("GET")
 	api.HandleFunc("/compatibility/{date1}/{date2}", javaneseHandler.GetWetonCompatibility).Methods("GET")
 	api.HandleFunc("/good-days/{birth_date}/{target_year}", javaneseHandler.GetGoodDays).Methods("GET")
+	api.HandleFunc("/good-days/{purpose}/{birth_date}/{target_year}", javaneseHandler.GetGoodDays).Methods("GET")
+	api.HandleFunc("/wedding-dates/{bride_birth}/{groom_birth}/{year}", javaneseHandler.GetWeddingDates).Methods("GET")
+	api.HandleFunc("/slametan/{date_of_death}", javaneseHandler.GetSlametan).Methods("GET")
+	api.HandleFunc("/bad-days/{year}", javaneseHandler.GetBadDays).Methods("GET")
+	api.HandleFunc("/bad-days/{birth_date}/{year}", javaneseHandler.GetBadDays).Methods("GET")
+	api.HandleFunc("/hijri/{date}", javaneseHandler.GetHijri).Methods("GET")
+	api.HandleFunc("/holidays/{year}", javaneseHandler.GetHolidays).Methods("GET")
+	api.HandleFunc("/holidays/{year}/{month}", javaneseHandler.GetHolidays).Methods("GET")
+	api.HandleFunc("/ical/weton/{birth_date:[0-9]{4}-[0-9]{2}-[0-9]{2}}.ics", javaneseHandler.GetWetonFeed).Methods("GET")
+	api.HandleFunc("/ical/slametan/{date_of_death:[0-9]{4}-[0-9]{2}-[0-9]{2}}.ics", javaneseHandler.GetSlametanFeed).Methods("GET")
+	api.HandleFunc("/ical/holidays.ics", javaneseHandler.GetHolidaysFeed).Methods("GET")
+	api.HandleFunc("/ical/holidays/{year:[0-9]+}.ics", javaneseHandler.GetHolidaysFeed).Methods("GET")
+	api.HandleFunc("/naga/{date}", javaneseHandler.GetNaga).Methods("GET")
+	api.HandleFunc("/analysis/{date}", javaneseHandler.GetAnalysisByDate).Methods("GET")
+	api.HandleFunc("/analysis/weton/{weton}", javaneseHandler.GetAnalysisByWeton).Methods("GET")
 
+	api.HandleFunc("/weton/{weton}/range/{start}/{end}", javaneseHandler.FilterByWetonRange).Methods("GET")
 	api.HandleFunc("/weton/{weton}/{year}", javaneseHandler.FilterByWeton).Methods("GET")
 	api.HandleFunc("/weton/{weton}/{year}/{month}", javaneseHandler.FilterByWeton).Methods("GET")
+	api.HandleFunc("/wuku/{wuku}/{year}", javaneseHandler.FilterByWuku).Methods("GET")
+	api.HandleFunc("/wuku/{wuku}/{year}/{month}", javaneseHandler.FilterByWuku).Methods("GET")
+	api.HandleFunc("/wewaran/{cycle}/{value}/{year}", javaneseHandler.FilterByWewaran).Methods("GET")
+	api.HandleFunc("/wewaran/{cycle}/{value}/{year}/{month}", javaneseHandler.FilterByWewaran).Methods("GET")
 
 	api.HandleFunc("/{path:.*}", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Timezone")
 		w.WriteHeader(http.StatusOK)
 	}).Methods("OPTIONS")
 }
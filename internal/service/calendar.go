@@ -0,0 +1,114 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yuxxeun/jakal/pkg/calendar"
+)
+
+// Batas tahun Masehi yang dilayani; penanggalan Jawa baru dihitung mulai
+// 1 Sura 1555 (8 Juli 1633) dan maju dengan aritmetika windu
+const (
+	MinYear = 1
+	MaxYear = 9999
+)
+
+// PreEpochNote menjelaskan mengapa tanggal Jawa kosong sebelum 8 Juli 1633
+const PreEpochNote = "Kalender Jawa Sultan Agung baru berlaku sejak 1 Sura 1555 (8 Juli 1633); " +
+	"untuk tanggal sebelumnya hanya tahun Saka dan siklus hari (weton, wuku, wewaran) yang dihitung"
+
+// maxDay - hari terakhir yang dilayani, 31 Desember MaxYear
+var maxDay = calendar.FromGregorian(MaxYear, time.December, 31)
+
+// MaxJavaneseYear - tahun Jawa terakhir yang seluruhnya jatuh sebelum akhir MaxYear
+var MaxJavaneseYear = calendar.ToJavanese(maxDay, KurupHistoris).Year - 1
+
+// dayRange mengembalikan hari pertama dan terakhir satu bulan, atau satu
+// tahun penuh bila month bernilai 0, menurut sistem kalender service.
+// Pada sistem historis, Oktober 1582 hanya berumur 21 hari.
+func (s *JavaneseCalendarService) dayRange(year, month int) (first, last calendar.JDN) {
+	firstMonth, nextYear, nextMonth := time.January, year+1, time.January
+	if month != 0 {
+		firstMonth = time.Month(month)
+		nextYear, nextMonth = year, time.Month(month+1)
+		if month == 12 {
+			nextYear, nextMonth = year+1, time.January
+		}
+	}
+
+	// Tanggal 1 selalu ada di setiap sistem kalender
+	first, _ = calendar.FromDate(s.system, year, firstMonth, 1)
+	next, _ := calendar.FromDate(s.system, nextYear, nextMonth, 1)
+	return first, next - 1
+}
+
+// ParseDate membaca tanggal YYYY-MM-DD menurut sistem kalender service, jadi
+// secara default 1500-02-29 dibaca sebagai tanggal Julian yang sah
+func (s *JavaneseCalendarService) ParseDate(value string) (time.Time, error) {
+	parts := strings.Split(value, "-")
+	if len(parts) != 3 || len(parts[0]) != 4 || len(parts[1]) != 2 || len(parts[2]) != 2 {
+		return time.Time{}, fmt.Errorf("format tanggal harus YYYY-MM-DD")
+	}
+
+	var numbers [3]int
+	for i, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil || number < 0 {
+			return time.Time{}, fmt.Errorf("format tanggal harus YYYY-MM-DD")
+		}
+		numbers[i] = number
+	}
+
+	if numbers[0] < MinYear {
+		return time.Time{}, fmt.Errorf("tahun harus antara %d - %d", MinYear, MaxYear)
+	}
+
+	day, err := calendar.FromDate(s.system, numbers[0], time.Month(numbers[1]), numbers[2])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("tanggal %s tidak ada dalam kalender %s", value, s.systemLabel())
+	}
+	return day.Time(), nil
+}
+
+// WithCalendarSystem mengembalikan salinan service yang membaca tanggal
+// Masehi dengan sistem kalender tertentu (historis, gregorian, julian)
+func (s *JavaneseCalendarService) WithCalendarSystem(system calendar.System) *JavaneseCalendarService {
+	clone := *s
+	clone.system = system
+	return &clone
+}
+
+func (s *JavaneseCalendarService) systemLabel() string {
+	switch s.system {
+	case calendar.SystemGregorian:
+		return "Gregorian"
+	case calendar.SystemJulian:
+		return "Julian"
+	}
+	return "historis (Julian sebelum 15 Oktober 1582)"
+}
+
+// ParseJavaneseMonth menerima nomor bulan (1-12) atau nama bulan Jawa,
+// misalnya "mulud" atau "bakda-mulud"
+func ParseJavaneseMonth(value string) (int, bool) {
+	if month, err := strconv.Atoi(value); err == nil {
+		return month, month >= 1 && month <= 12
+	}
+	key := strings.ReplaceAll(strings.ToLower(value), "-", " ")
+	for i, name := range calendar.MonthNames {
+		if strings.ToLower(name) == key || strings.ReplaceAll(strings.ToLower(name), " ", "") == key {
+			return i + 1, true
+		}
+	}
+	return 0, false
+}
+
+func javaneseYearType(year int) string {
+	if calendar.IsKabisat(year) {
+		return "kabisat"
+	}
+	return "wastu"
+}
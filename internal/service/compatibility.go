@@ -0,0 +1,113 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/yuxxeun/jakal/internal/model"
+)
+
+type compatibilityVerdict struct {
+	verdict     string
+	description string
+	favorable   bool
+}
+
+// Petungan sisa 8 dari jumlah neptu pasangan, indeks 0 untuk sisa 8 (habis dibagi)
+var compatibilityMod8 = []compatibilityVerdict{
+	{"Pesthi", "Rumah tangga rukun, tenteram dan damai sampai tua", true},
+	{"Pegat", "Sering menghadapi masalah ekonomi maupun kekuasaan yang dapat berujung perpisahan", false},
+	{"Ratu", "Pasangan dihormati dan disegani tetangga serta lingkungannya", true},
+	{"Jodoh", "Saling menerima kelebihan dan kekurangan, rumah tangga langgeng", true},
+	{"Topo", "Awal berumah tangga penuh kesulitan, namun kelak hidup bahagia", true},
+	{"Tinari", "Mudah mencari rezeki dan sering mendapat keberuntungan", true},
+	{"Padu", "Sering bertengkar karena hal kecil, namun tidak sampai bercerai", false},
+	{"Sujanan", "Sering bertengkar dan rawan perselingkuhan", false},
+}
+
+// Petungan sisa 7 dari jumlah neptu pasangan, indeks 0 untuk sisa 7 (habis dibagi)
+var compatibilityMod7 = []compatibilityVerdict{
+	{"Lebu Katiup Angin", "Hidup sering berpindah dan cita-cita sulit tercapai", false},
+	{"Wasesa Segara", "Berwibawa, pemaaf dan berbudi luhur", true},
+	{"Tunggak Semi", "Rezeki mudah mengalir", true},
+	{"Satriya Wibawa", "Mendapat kemuliaan dan keluhuran", true},
+	{"Sumur Sinaba", "Menjadi tempat bertanya dan berguru banyak orang", true},
+	{"Satriya Wirang", "Sering mengalami kesedihan dan mendapat malu", false},
+	{"Bumi Kepetak", "Bekerja keras namun hidup sering susah", false},
+}
+
+// Petungan sangan: sisa 9 neptu masing-masing pasangan dipasangkan,
+// kunci berupa "a-b" dengan a <= b (sisa 0 dihitung 9)
+var compatibilityMod9 = map[string]compatibilityVerdict{
+	"1-1": {"Baik", "Rumah tangga baik", true},
+	"1-2": {"Baik", "Rumah tangga baik", true},
+	"1-3": {"Kuat", "Kuat namun rawan rusak", false},
+	"1-4": {"Celaka", "Banyak celaka", false},
+	"1-5": {"Pegat", "Rawan bercerai", false},
+	"1-6": {"Jauh Rezeki", "Jauh dari rezeki", false},
+	"1-7": {"Banyak Musuh", "Banyak musuh", false},
+	"1-8": {"Sengsara", "Hidup sengsara", false},
+	"1-9": {"Pelindung", "Menjadi tempat berlindung", true},
+	"2-2": {"Selamat", "Selamat dan banyak rezeki", true},
+	"2-3": {"Wafat", "Salah satu cepat wafat", false},
+	"2-4": {"Godaan", "Banyak godaan", false},
+	"2-5": {"Celaka", "Banyak celaka", false},
+	"2-6": {"Kaya", "Cepat kaya", true},
+	"2-7": {"Kehilangan Anak", "Anak banyak yang meninggal", false},
+	"2-8": {"Dekat Rezeki", "Dekat dengan rezeki", true},
+	"2-9": {"Banyak Rezeki", "Banyak rezeki", true},
+	"3-3": {"Melarat", "Hidup melarat", false},
+	"3-4": {"Celaka", "Banyak celaka", false},
+	"3-5": {"Pegat", "Cepat berpisah", false},
+	"3-6": {"Bahagia", "Mendapat kebahagiaan", true},
+	"3-7": {"Celaka", "Banyak celaka", false},
+	"3-8": {"Wafat", "Salah satu cepat wafat", false},
+	"3-9": {"Banyak Rezeki", "Banyak rezeki", true},
+	"4-4": {"Sakit", "Sering sakit", false},
+	"4-5": {"Godaan", "Banyak godaan", false},
+	"4-6": {"Banyak Rezeki", "Banyak rezeki", true},
+	"4-7": {"Melarat", "Hidup melarat", false},
+	"4-8": {"Halangan", "Banyak halangan", false},
+	"4-9": {"Kalah", "Salah satu kalah", false},
+	"5-5": {"Beruntung", "Terus-menerus beruntung", true},
+	"5-6": {"Sedikit Rezeki", "Rezeki sedikit", false},
+	"5-7": {"Rezeki Tidak Tetap", "Rezeki tidak tetap", false},
+	"5-8": {"Halangan", "Banyak halangan", false},
+	"5-9": {"Sedikit Rezeki", "Rezeki sedikit", false},
+	"6-6": {"Celaka", "Besar celakanya", false},
+	"6-7": {"Rukun", "Rumah tangga rukun", true},
+	"6-8": {"Banyak Musuh", "Banyak musuh", false},
+	"6-9": {"Sengsara", "Hidup sengsara", false},
+	"7-7": {"Dihukum Pasangan", "Suami dikuasai istri", false},
+	"7-8": {"Celaka", "Celaka karena diri sendiri", false},
+	"7-9": {"Langgeng", "Rumah tangga langgeng", true},
+	"8-8": {"Dikasihi", "Dikasihi banyak orang", true},
+	"8-9": {"Celaka", "Banyak celaka", false},
+	"9-9": {"Susah Rezeki", "Sulit mencari rezeki", false},
+}
+
+// wetonNeptu menghitung neptu dari string weton, misalnya "Selasa Legi"
+func (s *JavaneseCalendarService) wetonNeptu(weton string) int {
+	parts := strings.Fields(weton)
+	if len(parts) != 2 {
+		return 0
+	}
+	return s.GetDayNeptu(parts[0]) + s.GetPasaranNeptu(parts[1])
+}
+
+func remainderOf(value, divisor int) int {
+	remainder := value % divisor
+	if remainder == 0 {
+		return divisor
+	}
+	return remainder
+}
+
+func newCompatibilityMethod(method string, verdict compatibilityVerdict, remainders ...int) *model.CompatibilityMethod {
+	return &model.CompatibilityMethod{
+		Method:      method,
+		Remainders:  remainders,
+		Verdict:     verdict.verdict,
+		Description: verdict.description,
+		Favorable:   verdict.favorable,
+	}
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yuxxeun/jakal/internal/model"
+)
+
+// Tujuan pencarian hari baik yang didukung
+const (
+	PurposeUmum      = "umum"
+	PurposeNikah     = "nikah"
+	PurposePindah    = "pindah-rumah"
+	PurposeBukaUsaha = "buka-usaha"
+	PurposeTanam     = "tanam"
+	PurposeKhitan    = "khitan"
+	PurposeBepergian = "bepergian"
+)
+
+// goodDayContext berisi tanggal yang diperiksa dan weton lahir pemohon
+type goodDayContext struct {
+	date  *model.JavaneseDate
+	birth *model.JavaneseDate
+}
+
+type goodDayRule struct {
+	name        string
+	description string
+	check       func(ctx goodDayContext) bool
+	// wetonOnly menandai aturan yang hanya membaca weton dan neptu tanggal,
+	// sehingga hasilnya berulang tiap 35 hari
+	wetonOnly bool
+}
+
+type goodDayRuleSet struct {
+	purpose string
+	rules   []goodDayRule
+}
+
+var sriLungguhGedhong = []string{"Pati", "Sri", "Lungguh", "Gedhong", "Lara"}
+
+// neptuCountRule - jumlah neptu hari dan weton lahir dihitung Sri, Lungguh,
+// Gedhong, Lara, Pati; hanya hitungan yang disebut yang lolos
+func neptuCountRule(allowed ...string) goodDayRule {
+	return goodDayRule{
+		name:        "neptu_" + strings.ToLower(strings.Join(allowed, "_")),
+		description: "Jumlah neptu hari dan weton lahir jatuh pada " + strings.Join(allowed, "/"),
+		check: func(ctx goodDayContext) bool {
+			count := sriLungguhGedhong[(ctx.date.Neptu+ctx.birth.Neptu)%5]
+			for _, name := range allowed {
+				if name == count {
+					return true
+				}
+			}
+			return false
+		},
+		wetonOnly: true,
+	}
+}
+
+// forbiddenMonthRule - hari di bulan Jawa tertentu tidak boleh dipakai
+func forbiddenMonthRule(months ...string) goodDayRule {
+	return goodDayRule{
+		name:        "bukan_bulan_larangan",
+		description: "Tidak jatuh di bulan " + strings.Join(months, ", "),
+		check: func(ctx goodDayContext) bool {
+			for _, month := range months {
+				if ctx.date.JavaneseMonthName == month {
+					return false
+				}
+			}
+			return true
+		},
+	}
+}
+
+// mangsaRule - hanya mangsa tertentu dalam Pranata Mangsa yang lolos
+func mangsaRule(mangsas ...string) goodDayRule {
+	return goodDayRule{
+		name:        "pranata_mangsa",
+		description: "Jatuh pada mangsa " + strings.Join(mangsas, ", "),
+		check: func(ctx goodDayContext) bool {
+			for _, mangsa := range mangsas {
+				if ctx.date.PranataMangsa.Name == mangsa {
+					return true
+				}
+			}
+			return false
+		},
+	}
+}
+
+var notBadDayRule = goodDayRule{
+	name:        "bukan_dina_ala",
+	description: "Bukan taliwangke, samparwangke, dina sangar maupun naas sasi",
+	check: func(ctx goodDayContext) bool {
+		return len(ctx.date.BadDays) == 0
+	},
+}
+
+var notBirthWetonRule = goodDayRule{
+	name:        "bukan_weton_lahir",
+	description: "Tidak jatuh pada weton lahir sendiri",
+	check: func(ctx goodDayContext) bool {
+		return ctx.date.Weton != ctx.birth.Weton
+	},
+	wetonOnly: true,
+}
+
+var goodDayRuleSets = []goodDayRuleSet{
+	{PurposeUmum, []goodDayRule{neptuCountRule("Sri", "Lungguh", "Gedhong"), notBadDayRule}},
+	{PurposeNikah, []goodDayRule{neptuCountRule("Sri", "Lungguh", "Gedhong"), forbiddenMonthRule("Sura", "Pasa"), notBadDayRule, notBirthWetonRule}},
+	{PurposePindah, []goodDayRule{neptuCountRule("Sri", "Gedhong"), forbiddenMonthRule("Sura"), notBadDayRule}},
+	{PurposeBukaUsaha, []goodDayRule{neptuCountRule("Sri", "Gedhong"), forbiddenMonthRule("Sura", "Sela"), notBadDayRule}},
+	{PurposeTanam, []goodDayRule{neptuCountRule("Sri", "Lungguh"), mangsaRule("Kasa", "Kalima", "Kanem", "Kapitu"), notBadDayRule}},
+	{PurposeKhitan, []goodDayRule{neptuCountRule("Sri", "Lungguh", "Gedhong"), forbiddenMonthRule("Sura", "Pasa"), notBadDayRule}},
+	{PurposeBepergian, []goodDayRule{neptuCountRule("Sri", "Lungguh", "Gedhong"), notBadDayRule}},
+}
+
+// GoodDayPurposes mengembalikan daftar tujuan yang dapat dipakai
+func GoodDayPurposes() []string {
+	var purposes []string
+	for _, set := range goodDayRuleSets {
+		purposes = append(purposes, set.purpose)
+	}
+	return purposes
+}
+
+func findGoodDayRuleSet(purpose string) (goodDayRuleSet, error) {
+	key := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(purpose)), " ", "-")
+	if key == "" {
+		key = PurposeUmum
+	}
+	for _, set := range goodDayRuleSets {
+		if set.purpose == key {
+			return set, nil
+		}
+	}
+	return goodDayRuleSet{}, fmt.Errorf("tujuan tidak dikenal: %s", purpose)
+}
+
+// evaluate mengembalikan deskripsi aturan yang lolos, atau false bila ada yang gagal
+func (set goodDayRuleSet) evaluate(ctx goodDayContext) ([]*model.PassedRule, bool) {
+	var passed []*model.PassedRule
+	for _, rule := range set.rules {
+		if !rule.check(ctx) {
+			return nil, false
+		}
+		passed = append(passed, &model.PassedRule{Rule: rule.name, Description: rule.description})
+	}
+	return passed, true
+}
+
+// wetonPasses hanya memeriksa aturan wetonOnly
+func (set goodDayRuleSet) wetonPasses(ctx goodDayContext) bool {
+	for _, rule := range set.rules {
+		if rule.wetonOnly && !rule.check(ctx) {
+			return false
+		}
+	}
+	return true
+}
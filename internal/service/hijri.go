@@ -0,0 +1,81 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yuxxeun/jakal/internal/model"
+	"github.com/yuxxeun/jakal/pkg/calendar"
+)
+
+// Metode perhitungan tanggal Hijriah
+const (
+	HijriTabular = "tabular"
+	HijriHisab   = "hisab"
+)
+
+// MaxHijriAdjustment - koreksi tabular paling jauh yang diterima (hari)
+const MaxHijriAdjustment = 3
+
+// Hisab memakai kriteria ijtimak sebelum maghrib di Yogyakarta
+var hisabObserver = calendar.HisabObserver{
+	UTCOffset: float64(DefaultLocation.UTCOffset),
+	Sunset: func(day calendar.JDN) float64 {
+		return sunsetMinutes(day.Time(), DefaultLocation) / 60
+	},
+}
+
+// ParseHijriMethod menerima "tabular" (urfi) atau "hisab"; kosong berarti tabular
+func ParseHijriMethod(name string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", HijriTabular, "urfi":
+		return HijriTabular, nil
+	case HijriHisab:
+		return HijriHisab, nil
+	}
+	return "", fmt.Errorf("metode hijriah tidak dikenal: %s", name)
+}
+
+// WithHijri mengembalikan salinan service dengan metode Hijriah tertentu.
+// adjustment menggeser hasil tabular beberapa hari agar sesuai hasil rukyat.
+func (s *JavaneseCalendarService) WithHijri(method string, adjustment int) *JavaneseCalendarService {
+	clone := *s
+	clone.hijriMethod = method
+	clone.hijriAdjustment = adjustment
+	return &clone
+}
+
+// ConvertToHijri menghitung tanggal Hijriah dengan metode tertentu
+func (s *JavaneseCalendarService) ConvertToHijri(day calendar.JDN, method string) *model.HijriDate {
+	adjustment := 0
+	if method != HijriHisab {
+		method = HijriTabular
+		adjustment = s.hijriAdjustment
+	}
+	// Koreksi ikut digeser sebelum dibandingkan dengan awal tahun Hijriah
+	if day+calendar.JDN(adjustment) < calendar.HijriEpoch {
+		return nil
+	}
+
+	var date calendar.HijriDate
+	if method == HijriHisab {
+		date = calendar.ToHijriHisab(day, hisabObserver)
+	} else {
+		date = calendar.ToHijri(day, adjustment)
+	}
+	// Hisab bisa menaruh 1 Muharram 1 H sehari dari epoch tabular
+	if date.Year < 1 {
+		return nil
+	}
+
+	monthName := calendar.HijriMonthNames[date.Month-1]
+	return &model.HijriDate{
+		Day:        date.Day,
+		Month:      date.Month,
+		MonthName:  monthName,
+		Year:       date.Year,
+		Formatted:  fmt.Sprintf("%d %s %d H", date.Day, monthName, date.Year),
+		Method:     method,
+		Adjustment: adjustment,
+	}
+}
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/yuxxeun/jakal/pkg/calendar"
+)
+
+// Koreksi hijri_adjust tidak boleh menghasilkan tahun 0 H di sekitar epoch
+func TestConvertToHijriEpoch(t *testing.T) {
+	epoch := calendar.FromJulian(622, time.July, 16)
+	tests := []struct {
+		day        calendar.JDN
+		adjustment int
+		want       bool
+	}{
+		{epoch, 0, true},
+		{epoch - 1, 0, false},
+		{epoch, -3, false},
+		{epoch + 3, -3, true},
+		{epoch - 3, 3, true},
+		{epoch - 4, 3, false},
+	}
+
+	for _, tt := range tests {
+		s := NewJavaneseCalendarService().WithHijri(HijriTabular, tt.adjustment)
+		got := s.ConvertToHijri(tt.day, HijriTabular)
+		if (got != nil) != tt.want {
+			t.Errorf("ConvertToHijri(%s, koreksi %d) = %+v, tersedia %v", tt.day, tt.adjustment, got, tt.want)
+			continue
+		}
+		if got != nil && got.Year < 1 {
+			t.Errorf("ConvertToHijri(%s, koreksi %d) = %s", tt.day, tt.adjustment, got.Formatted)
+		}
+	}
+
+	if got := NewJavaneseCalendarService().ConvertToHijri(epoch-1, HijriHisab); got != nil && got.Year < 1 {
+		t.Errorf("hisab sebelum epoch = %s", got.Formatted)
+	}
+}
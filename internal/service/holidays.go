@@ -0,0 +1,188 @@
+package service
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"strings"
+	"time"
+
+	"github.com/yuxxeun/jakal/internal/model"
+	"github.com/yuxxeun/jakal/pkg/calendar"
+)
+
+// Jenis hari libur yang dapat muncul di field holidays
+const (
+	HolidayJawa        = "jawa"
+	HolidayNasional    = "nasional"
+	HolidayCutiBersama = "cuti_bersama"
+)
+
+// Libur nasional dan cuti bersama ditetapkan SKB tiap tahun, jadi disimpan
+// satu berkas per tahun yang bisa diganti tanpa mengubah kode
+//
+//go:embed data/holidays/*.json
+var holidayFiles embed.FS
+
+type holidayFile struct {
+	Year     int            `json:"year"`
+	Version  string         `json:"version"`
+	Source   string         `json:"source"`
+	Note     string         `json:"note"`
+	Holidays []holidayEntry `json:"holidays"`
+}
+
+type holidayEntry struct {
+	Date string `json:"date"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// holidayData berisi libur nasional per tanggal Masehi dan versi data per tahun
+type holidayData struct {
+	byDate  map[string][]*model.Holiday
+	sources map[int]*model.HolidaySource
+}
+
+// javaneseObservance - hari besar Jawa yang jatuh pada tanggal Jawa tetap
+type javaneseObservance struct {
+	name        string
+	month       int
+	day         int
+	description string
+}
+
+var javaneseObservances = []javaneseObservance{
+	{"1 Sura", 1, 1, "Tahun baru Jawa, malamnya diperingati dengan tirakatan dan kirab pusaka"},
+	{"Grebeg Mulud", 3, 12, "Grebeg keraton memperingati kelahiran Nabi Muhammad, puncak Sekaten"},
+	{"Selikuran", 9, 21, "Malem selikuran, awal sepuluh malam terakhir bulan Pasa"},
+	{"Grebeg Pasa", 10, 1, "Grebeg keraton pada hari raya Idul Fitri"},
+	{"Grebeg Besar", 12, 10, "Grebeg keraton pada hari raya Idul Adha"},
+}
+
+func mustLoadHolidays(fsys fs.FS) holidayData {
+	data := holidayData{
+		byDate:  make(map[string][]*model.Holiday),
+		sources: make(map[int]*model.HolidaySource),
+	}
+
+	paths, err := fs.Glob(fsys, "data/holidays/*.json")
+	if err != nil {
+		panic(fmt.Sprintf("data libur tidak valid: %v", err))
+	}
+
+	for _, path := range paths {
+		content, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			panic(fmt.Sprintf("data libur tidak valid: %v", err))
+		}
+
+		var file holidayFile
+		if err := json.Unmarshal(content, &file); err != nil {
+			panic(fmt.Sprintf("data libur %s tidak valid: %v", path, err))
+		}
+
+		prefix := fmt.Sprintf("%04d-", file.Year)
+		for _, entry := range file.Holidays {
+			if !strings.HasPrefix(entry.Date, prefix) {
+				panic(fmt.Sprintf("data libur %s: tanggal %s bukan tahun %d", path, entry.Date, file.Year))
+			}
+			if entry.Type != HolidayNasional && entry.Type != HolidayCutiBersama {
+				panic(fmt.Sprintf("data libur %s: jenis libur %q tidak dikenal", path, entry.Type))
+			}
+			data.byDate[entry.Date] = append(data.byDate[entry.Date], &model.Holiday{
+				Name: entry.Name,
+				Type: entry.Type,
+			})
+		}
+
+		data.sources[file.Year] = &model.HolidaySource{
+			Year:    file.Year,
+			Version: file.Version,
+			Source:  file.Source,
+			Note:    file.Note,
+		}
+	}
+
+	return data
+}
+
+// ParseHolidayType menerima jenis libur untuk ?type=, kosong berarti semua jenis
+func ParseHolidayType(value string) (string, error) {
+	key := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(value)), "-", "_")
+	switch key {
+	case "", HolidayJawa, HolidayNasional, HolidayCutiBersama:
+		return key, nil
+	}
+	return "", fmt.Errorf("jenis libur tidak dikenal: %s", value)
+}
+
+// holidaysFor mengumpulkan libur nasional dari berkas data dan hari besar
+// Jawa yang dihitung dari tanggal Jawa menurut kurup service
+func (s *JavaneseCalendarService) holidaysFor(day calendar.JDN, jd *model.JavaneseDate) []*model.Holiday {
+	holidays := append([]*model.Holiday(nil), s.holidays.byDate[jd.GregorianDate]...)
+
+	for _, observance := range javaneseObservances {
+		if observance.month == jd.JavaneseMonth && observance.day == jd.JavaneseDay {
+			holidays = append(holidays, &model.Holiday{
+				Name:        observance.name,
+				Type:        HolidayJawa,
+				Description: observance.description,
+			})
+		}
+	}
+
+	// Rebo Wekasan: Rabu terakhir bulan Sapar
+	if jd.JavaneseMonth == 2 && day.Weekday() == 3 &&
+		jd.JavaneseDay+7 > calendar.MonthLength(jd.JavaneseYear, 2, s.kurup) {
+		holidays = append(holidays, &model.Holiday{
+			Name:        "Rebo Wekasan",
+			Type:        HolidayJawa,
+			Description: "Rabu terakhir bulan Sapar, diperingati dengan tolak bala",
+		})
+	}
+
+	return holidays
+}
+
+// GetHolidays - daftar hari libur dalam satu tahun Masehi, atau satu bulan
+// bila month diisi, dengan filter jenis libur bila holidayType tidak kosong
+func (s *JavaneseCalendarService) GetHolidays(year, month int, holidayType string) []*model.HolidayDate {
+	first, last := s.dayRange(year, month)
+	return s.holidaysBetween(first, last, holidayType)
+}
+
+// GetHolidaysInRange - daftar hari libur di antara start dan end
+func (s *JavaneseCalendarService) GetHolidaysInRange(start, end time.Time, holidayType string) []*model.HolidayDate {
+	return s.holidaysBetween(calendar.FromTime(start), calendar.FromTime(end), holidayType)
+}
+
+func (s *JavaneseCalendarService) holidaysBetween(first, last calendar.JDN, holidayType string) []*model.HolidayDate {
+	var holidays []*model.HolidayDate
+
+	for day := first; day <= last; day++ {
+		jd := s.convertDay(day)
+		for _, holiday := range jd.Holidays {
+			if holidayType != "" && holiday.Type != holidayType {
+				continue
+			}
+			holidays = append(holidays, &model.HolidayDate{
+				GregorianDate: jd.GregorianDate,
+				Weton:         jd.Weton,
+				JavaneseDate:  formatJavaneseDate(jd),
+				Name:          holiday.Name,
+				Type:          holiday.Type,
+				Description:   holiday.Description,
+			})
+		}
+	}
+
+	return holidays
+}
+
+// HolidaySource mengembalikan versi data libur nasional untuk satu tahun,
+// atau nil bila tahun tersebut belum memiliki berkas data
+func (s *JavaneseCalendarService) HolidaySource(year int) *model.HolidaySource {
+	return s.holidays.sources[year]
+}
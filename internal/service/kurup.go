@@ -0,0 +1,30 @@
+package service
+
+import (
+	"fmt"
+
+	"github.com/yuxxeun/jakal/pkg/calendar"
+)
+
+// Kurup menentukan petungan yang dipakai untuk menghitung tanggal Jawa.
+// KurupHistoris mengikuti pergantian kurup sesuai sejarah, sedangkan kurup
+// lain menghitung seluruh tanggal dari awal kurup tersebut tanpa pergeseran.
+type Kurup = calendar.Kurup
+
+const (
+	KurupHistoris = calendar.KurupHistoris
+	KurupAjumgi   = calendar.KurupAjumgi
+	KurupAmiswon  = calendar.KurupAmiswon
+	KurupAboge    = calendar.KurupAboge
+	KurupAsapon   = calendar.KurupAsapon
+	KurupAnenge   = calendar.KurupAnenge
+)
+
+// ParseKurup mengubah nilai query ?kurup= menjadi Kurup
+func ParseKurup(name string) (Kurup, error) {
+	kurup, err := calendar.ParseKurup(name)
+	if err != nil {
+		return KurupHistoris, fmt.Errorf("kurup tidak dikenal: %s", name)
+	}
+	return kurup, nil
+}
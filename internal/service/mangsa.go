@@ -0,0 +1,89 @@
+package service
+
+import (
+	"time"
+
+	"github.com/yuxxeun/jakal/internal/model"
+	"github.com/yuxxeun/jakal/pkg/calendar"
+)
+
+type mangsaData struct {
+	name   string
+	month  time.Month
+	day    int
+	candra string
+	watak  string
+}
+
+// Pranata Mangsa versi Pakubuwana VII (1855). Tahun mangsa dimulai 22 Juni,
+// mangsa Kawolu sampai Sadha jatuh pada tahun Masehi berikutnya.
+var mangsaList = []mangsaData{
+	{"Kasa", time.June, 22, "Sotya murca ing embanan",
+		"Daun berguguran, kayu mengering, belalang masuk ke tanah. Petani membakar jerami dan mulai menanam palawija"},
+	{"Karo", time.August, 2, "Bantala rengka",
+		"Tanah retak-retak karena kering, pohon randu dan mangga mulai bersemi. Palawija mulai tumbuh"},
+	{"Katelu", time.August, 25, "Suta manut ing bapa",
+		"Tanaman merambat naik ke lanjaran, ubi-ubian mulai dipanen, rebung bambu bermunculan"},
+	{"Kapat", time.September, 18, "Waspa kumembeng jroning kalbu",
+		"Mata air mengering, pohon kapuk berbuah, burung pipit dan manyar bersarang. Sawah disiapkan untuk padi gaga"},
+	{"Kalima", time.October, 13, "Pancuran emas sumawur ing jagad",
+		"Hujan mulai turun, pohon asam bersemi, ulat bermunculan. Saluran air diperbaiki dan padi gaga mulai ditanam"},
+	{"Kanem", time.November, 9, "Rasa mulya kasucen",
+		"Buah-buahan seperti durian, rambutan dan manggis masak. Petani menyebar benih padi di pesemaian"},
+	{"Kapitu", time.December, 22, "Wisa kentar ing maruta",
+		"Hujan lebat disertai angin, sungai meluap dan banyak penyakit. Bibit padi dipindahkan ke sawah"},
+	{"Kawolu", time.February, 3, "Anjrah jroning kayun",
+		"Padi mulai menghijau dan bunting, musim kawin kucing, uret bermunculan"},
+	{"Kasanga", time.March, 1, "Wedaring wacana mulya",
+		"Padi berbunga dan sebagian berbuah, garengpung dan jangkrik mulai berbunyi"},
+	{"Kadasa", time.March, 26, "Gedong mineb jroning kalbu",
+		"Padi menguning dan siap dipanen, hewan-hewan mulai bunting, burung membuat sarang"},
+	{"Desta", time.April, 19, "Sotya sinarawedi",
+		"Musim panen padi, burung-burung menyuapi anaknya"},
+	{"Sadha", time.May, 12, "Tirta sah saking sasana",
+		"Udara dingin di pagi hari, orang jarang berkeringat. Padi dijemur dan disimpan di lumbung"},
+}
+
+// mangsaStart mengembalikan tanggal awal mangsa ke-index dalam tahun mangsa
+// yang dimulai pada 22 Juni tahun Masehi tertentu
+func mangsaStart(mangsaYear, index int) calendar.JDN {
+	if index == len(mangsaList) {
+		return calendar.FromGregorian(mangsaYear+1, mangsaList[0].month, mangsaList[0].day)
+	}
+	year := mangsaYear
+	if index >= 7 {
+		year++
+	}
+	return calendar.FromGregorian(year, mangsaList[index].month, mangsaList[index].day)
+}
+
+func buildPranataMangsa(mangsaYear, index int) *model.PranataMangsa {
+	start := mangsaStart(mangsaYear, index)
+	end := mangsaStart(mangsaYear, index+1) - 1
+	mangsa := mangsaList[index]
+
+	return &model.PranataMangsa{
+		Number: index + 1,
+		Name:   mangsa.name,
+		Start:  start.String(),
+		End:    end.String(),
+		Length: end.Sub(start) + 1,
+		Candra: mangsa.candra,
+		Watak:  mangsa.watak,
+	}
+}
+
+// pranataMangsaFor mencari mangsa yang sedang berlaku pada tanggal tertentu
+func pranataMangsaFor(day calendar.JDN) *model.PranataMangsa {
+	mangsaYear, _, _ := day.Gregorian()
+	if day < mangsaStart(mangsaYear, 0) {
+		mangsaYear--
+	}
+
+	index := 0
+	for index+1 < len(mangsaList) && day >= mangsaStart(mangsaYear, index+1) {
+		index++
+	}
+
+	return buildPranataMangsa(mangsaYear, index)
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/yuxxeun/jakal/internal/model"
+)
+
+// Nama aturan dina ala yang dapat muncul di field bad_days
+const (
+	RuleTaliwangke   = "taliwangke"
+	RuleSamparwangke = "samparwangke"
+	RuleDinaSangar   = "dina_sangar"
+	RuleNaasSasi     = "naas_sasi"
+	RuleNaasPribadi  = "naas_pribadi"
+)
+
+// Taliwangke dan samparwangke: pasangan wuku dan hari dalam pawukon
+var taliwangkeDays = map[string]string{
+	"Wukir":      "Senin",
+	"Kurantil":   "Selasa",
+	"Tolu":       "Rabu",
+	"Gumbreg":    "Kamis",
+	"Warigalit":  "Jumat",
+	"Warigagung": "Sabtu",
+}
+
+var samparwangkeDays = map[string]string{
+	"Bala":       "Minggu",
+	"Wugu":       "Senin",
+	"Wayang":     "Selasa",
+	"Kulawu":     "Rabu",
+	"Dukut":      "Kamis",
+	"Watugunung": "Jumat",
+}
+
+// Dina sangar: weton yang dihindari pada tiap bulan Jawa, urut dari Sura
+var dinaSangarWeton = []string{
+	"Jumat Wage", "Sabtu Kliwon", "Senin Pahing", "Selasa Pon",
+	"Jumat Legi", "Sabtu Pahing", "Senin Wage", "Selasa Kliwon",
+	"Kamis Pon", "Sabtu Legi", "Senin Kliwon", "Rabu Pahing",
+}
+
+// Naas sasi: tanggal Jawa yang naas pada tiap bulan, urut dari Sura
+var naasSasiDates = [][]int{
+	{6, 11}, {1, 20}, {1, 15}, {10, 20}, {1, 11}, {10, 14},
+	{2, 13}, {12, 26}, {7, 24}, {2, 10}, {2, 22}, {6, 20},
+}
+
+// badDayFlags memeriksa aturan dina ala yang tidak bergantung pada weton lahir
+func badDayFlags(jd *model.JavaneseDate) []*model.DayFlag {
+	var flags []*model.DayFlag
+
+	if day, ok := taliwangkeDays[jd.Wuku]; ok && day == jd.Day {
+		flags = append(flags, &model.DayFlag{
+			Rule:   RuleTaliwangke,
+			Reason: fmt.Sprintf("%s wuku %s termasuk dina taliwangke", jd.Day, jd.Wuku),
+		})
+	}
+
+	if day, ok := samparwangkeDays[jd.Wuku]; ok && day == jd.Day {
+		flags = append(flags, &model.DayFlag{
+			Rule:   RuleSamparwangke,
+			Reason: fmt.Sprintf("%s wuku %s termasuk dina samparwangke", jd.Day, jd.Wuku),
+		})
+	}
+
+	// Dina sangar dan naas sasi bergantung pada bulan Jawa
+	if jd.JavaneseMonth == 0 {
+		return flags
+	}
+
+	if dinaSangarWeton[jd.JavaneseMonth-1] == jd.Weton {
+		flags = append(flags, &model.DayFlag{
+			Rule:   RuleDinaSangar,
+			Reason: fmt.Sprintf("%s adalah dina sangar di bulan %s", jd.Weton, jd.JavaneseMonthName),
+		})
+	}
+
+	for _, date := range naasSasiDates[jd.JavaneseMonth-1] {
+		if date == jd.JavaneseDay {
+			flags = append(flags, &model.DayFlag{
+				Rule:   RuleNaasSasi,
+				Reason: fmt.Sprintf("Tanggal %d %s adalah tanggal naas", jd.JavaneseDay, jd.JavaneseMonthName),
+			})
+		}
+	}
+
+	return flags
+}
+
+// naasPribadiFlag - hitungan Sri, Lungguh, Gedhong, Lara, Pati dari jumlah
+// neptu weton lahir dan neptu hari; sisa 0 (Pati) adalah naas pribadi
+func naasPribadiFlag(jd *model.JavaneseDate, birth *model.JavaneseDate) *model.DayFlag {
+	if (jd.Neptu+birth.Neptu)%5 != 0 {
+		return nil
+	}
+	return &model.DayFlag{
+		Rule:   RuleNaasPribadi,
+		Reason: fmt.Sprintf("Neptu %s (%d) ditambah neptu weton lahir %s (%d) jatuh pada Pati", jd.Weton, jd.Neptu, birth.Weton, birth.Neptu),
+	}
+}
+
+// GetBadDays - daftar dina ala dalam satu tahun Masehi. Bila birthDate diisi,
+// naas pribadi dari weton lahir ikut diperiksa.
+func (s *JavaneseCalendarService) GetBadDays(year int, birthDate *time.Time) []*model.BadDay {
+	var birth *model.JavaneseDate
+	if birthDate != nil {
+		birth = s.ConvertToJavaneseDate(*birthDate)
+	}
+
+	var badDays []*model.BadDay
+
+	first, last := s.dayRange(year, 0)
+	for day := first; day <= last; day++ {
+		jd := s.convertDay(day)
+
+		flags := jd.BadDays
+		if birth != nil {
+			if flag := naasPribadiFlag(jd, birth); flag != nil {
+				flags = append(flags, flag)
+			}
+		}
+		if len(flags) == 0 {
+			continue
+		}
+
+		badDays = append(badDays, &model.BadDay{
+			GregorianDate: jd.GregorianDate,
+			Weton:         jd.Weton,
+			JavaneseDate:  formatJavaneseDate(jd),
+			Flags:         flags,
+		})
+	}
+
+	return badDays
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/yuxxeun/jakal/internal/model"
+	"github.com/yuxxeun/jakal/pkg/calendar"
+)
+
+var compassDirections = []string{"Timur", "Selatan", "Barat", "Utara"}
+
+// Naga tahun berpindah setiap dua tahun dalam windu, urut dari Alip
+var nagaTahunDirections = []int{0, 0, 1, 1, 2, 2, 3, 3}
+
+// nagaDinaDirection - neptu 7, 11, 15 di Timur; 8, 12, 16 di Selatan;
+// 9, 13, 17 di Barat; 10, 14, 18 di Utara
+func nagaDinaDirection(neptu int) int {
+	return (neptu - 7) % 4
+}
+
+// nagaSasiDirection - naga sasi berpindah setiap tiga bulan, mulai Sura di Timur
+func nagaSasiDirection(month int) int {
+	return (month - 1) / 3
+}
+
+// GetNaga menghitung arah naga dina, naga sasi dan naga tahun untuk satu tanggal
+func (s *JavaneseCalendarService) GetNaga(date time.Time) *model.Naga {
+	jd := s.ConvertToJavaneseDate(date)
+
+	positions := []*model.NagaPosition{
+		{
+			Kind:      "naga_dina",
+			Direction: compassDirections[nagaDinaDirection(jd.Neptu)],
+			Basis:     fmt.Sprintf("Neptu weton %s = %d", jd.Weton, jd.Neptu),
+		},
+	}
+	// Naga sasi dan naga tahun mengikuti bulan dan tahun Jawa
+	if jd.JavaneseYear != 0 {
+		positions = append(positions,
+			&model.NagaPosition{
+				Kind:      "naga_sasi",
+				Direction: compassDirections[nagaSasiDirection(jd.JavaneseMonth)],
+				Basis:     "Bulan " + jd.JavaneseMonthName,
+			},
+			&model.NagaPosition{
+				Kind:      "naga_tahun",
+				Direction: compassDirections[nagaTahunDirections[calendar.WinduYear(jd.JavaneseYear)]],
+				Basis:     fmt.Sprintf("Tahun %s %d", jd.JavaneseYearName, jd.JavaneseYear),
+			},
+		)
+	}
+
+	forbidden := make(map[string]bool)
+	var forbiddenDirections []string
+	for _, position := range positions {
+		if !forbidden[position.Direction] {
+			forbidden[position.Direction] = true
+			forbiddenDirections = append(forbiddenDirections, position.Direction)
+		}
+	}
+
+	var favorableDirections []string
+	for _, direction := range compassDirections {
+		if !forbidden[direction] {
+			favorableDirections = append(favorableDirections, direction)
+		}
+	}
+
+	return &model.Naga{
+		GregorianDate:       jd.GregorianDate,
+		Weton:               jd.Weton,
+		JavaneseDate:        formatJavaneseDate(jd),
+		Note:                jd.Note,
+		Positions:           positions,
+		ForbiddenDirections: forbiddenDirections,
+		FavorableDirections: favorableDirections,
+	}
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/yuxxeun/jakal/internal/model"
+	"github.com/yuxxeun/jakal/pkg/calendar"
+)
+
+// Tafsir watak 35 weton disimpan terpisah agar mudah direvisi tanpa mengubah kode
+//
+//go:embed data/primbon.json
+var primbonJSON []byte
+
+type primbonEntry struct {
+	Weton          string `json:"weton"`
+	Character      string `json:"character"`
+	Strength       string `json:"strength"`
+	Weakness       string `json:"weakness"`
+	Recommendation string `json:"recommendation"`
+	LuckyColor     string `json:"lucky_color"`
+	LuckyNumber    int    `json:"lucky_number"`
+}
+
+func mustLoadPrimbon(data []byte) map[string]primbonEntry {
+	var entries []primbonEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		panic(fmt.Sprintf("data primbon tidak valid: %v", err))
+	}
+
+	primbon := make(map[string]primbonEntry, len(entries))
+	for _, entry := range entries {
+		if _, exists := primbon[entry.Weton]; exists {
+			panic(fmt.Sprintf("data primbon: weton %q tercantum lebih dari sekali", entry.Weton))
+		}
+		primbon[entry.Weton] = entry
+	}
+
+	// Setiap weton harus punya tafsir; weton yang hilang akan menghasilkan
+	// analisis kosong tanpa ada yang menyadarinya
+	for _, day := range calendar.DayNames {
+		for _, pasaran := range calendar.PasaranNames {
+			if _, ok := primbon[day+" "+pasaran]; !ok {
+				panic(fmt.Sprintf("data primbon: weton %q tidak ada", day+" "+pasaran))
+			}
+		}
+	}
+	if len(primbon) != calendar.WetonCycle {
+		panic(fmt.Sprintf("data primbon berisi %d weton, seharusnya %d", len(primbon), calendar.WetonCycle))
+	}
+	return primbon
+}
+
+// AnalyzeWeton mengisi analisis watak dan data primbon untuk satu weton
+func (s *JavaneseCalendarService) AnalyzeWeton(weton string) (*model.WetonAnalysis, *model.PrimbonData, bool) {
+	entry, ok := s.primbon[weton]
+	if !ok {
+		return nil, nil, false
+	}
+
+	parts := strings.Fields(weton)
+	day, pasaran := parts[0], parts[1]
+	neptu := s.wetonNeptu(weton)
+
+	analysis := &model.WetonAnalysis{
+		Weton:          weton,
+		Day:            day,
+		Pasaran:        pasaran,
+		Neptu:          neptu,
+		DayNeptu:       s.GetDayNeptu(day),
+		PasaranNeptu:   s.GetPasaranNeptu(pasaran),
+		Character:      entry.Character,
+		Strength:       entry.Strength,
+		Weakness:       entry.Weakness,
+		Recommendation: entry.Recommendation,
+	}
+
+	primbon := &model.PrimbonData{
+		Weton:       weton,
+		Neptu:       neptu,
+		Character:   entry.Character,
+		LuckyColor:  entry.LuckyColor,
+		LuckyNumber: entry.LuckyNumber,
+	}
+
+	return analysis, primbon, true
+}
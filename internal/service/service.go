@@ -1,59 +1,99 @@
 package service
 
 import (
+	"fmt"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/yuxxeun/jakal/internal/model"
+	"github.com/yuxxeun/jakal/pkg/calendar"
 )
 
 type JavaneseCalendarService struct {
-	dayNames     []string
-	pasaranNames []string
-	dayNeptu     map[string]int
-	pasaranNeptu map[string]int
+	cycles   []dayCycle
+	primbon  map[string]primbonEntry
+	holidays holidayData
+	kurup    Kurup
+	location *time.Location
+	system   calendar.System
+
+	hijriMethod     string
+	hijriAdjustment int
 }
 
 func NewJavaneseCalendarService() *JavaneseCalendarService {
-	return &JavaneseCalendarService{
-		dayNames:     []string{"Minggu", "Senin", "Selasa", "Rabu", "Kamis", "Jumat", "Sabtu"},
-		pasaranNames: []string{"Legi", "Pahing", "Pon", "Wage", "Kliwon"},
-		dayNeptu: map[string]int{
-			"Minggu": 5,
-			"Senin":  4,
-			"Selasa": 3,
-			"Rabu":   7,
-			"Kamis":  8,
-			"Jumat":  6,
-			"Sabtu":  9,
-		},
-		pasaranNeptu: map[string]int{
-			"Legi":   5,
-			"Pahing": 9,
-			"Pon":    7,
-			"Wage":   4,
-			"Kliwon": 8,
-		},
-	}
+	s := &JavaneseCalendarService{}
+	s.cycles = s.buildDayCycles()
+	s.primbon = mustLoadPrimbon(primbonJSON)
+	s.holidays = mustLoadHolidays(holidayFiles)
+	s.location = mustLoadLocation(DefaultTimezone)
+	return s
+}
+
+// WithKurup mengembalikan salinan service yang menghitung dengan kurup tertentu
+func (s *JavaneseCalendarService) WithKurup(kurup Kurup) *JavaneseCalendarService {
+	clone := *s
+	clone.kurup = kurup
+	return &clone
 }
 
 func (s *JavaneseCalendarService) FilterByWeton(year int, month int, weton string) []model.JavaneseDate {
+	// Satu tahun penuh bila month 0, selain itu bulan tertentu
+	first, last := s.dayRange(year, month)
+	return s.filterWetonDays(first, last, weton)
+}
+
+// FilterByWetonRange - semua tanggal berweton tertentu di antara start dan end,
+// dihitung langsung tiap 35 hari sehingga rentang puluhan tahun tetap ringan
+func (s *JavaneseCalendarService) FilterByWetonRange(start, end time.Time, weton string) []model.JavaneseDate {
+	return s.filterWetonDays(calendar.FromTime(start), calendar.FromTime(end), weton)
+}
+
+func (s *JavaneseCalendarService) filterWetonDays(first, last calendar.JDN, weton string) []model.JavaneseDate {
+	dayIndex, pasaranIndex, ok := s.parseWeton(weton)
+	if !ok {
+		return nil
+	}
+
 	var results []model.JavaneseDate
+	for _, day := range calendar.WetonDays(first, last, dayIndex, pasaranIndex) {
+		results = append(results, *s.convertDay(day))
+	}
 
-	var start, end time.Time
-	if month == 0 {
-		// Untuk satu tahun penuh
-		start = time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)
-		end = time.Date(year, 12, 31, 0, 0, 0, 0, time.UTC)
-	} else {
-		// Untuk bulan tertentu
-		start = time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
-		end = start.AddDate(0, 1, -1) // Hari terakhir bulan tersebut
+	return results
+}
+
+// parseWeton mengembalikan indeks hari dan pasaran dari weton seperti "Jumat Kliwon"
+func (s *JavaneseCalendarService) parseWeton(weton string) (dayIndex, pasaranIndex int, ok bool) {
+	parts := strings.Fields(weton)
+	if len(parts) != 2 {
+		return 0, 0, false
 	}
 
-	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
-		jd := s.ConvertToJavaneseDate(d)
-		if jd.Weton == weton {
+	dayIndex = indexOf(calendar.DayNames, parts[0])
+	pasaranIndex = indexOf(calendar.PasaranNames, parts[1])
+	return dayIndex, pasaranIndex, dayIndex >= 0 && pasaranIndex >= 0
+}
+
+// indexOf mencari nama hari atau pasaran tanpa membedakan huruf besar,
+// -1 bila tidak ada
+func indexOf(names []string, name string) int {
+	for i, candidate := range names {
+		if strings.EqualFold(candidate, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *JavaneseCalendarService) FilterByWuku(year int, month int, wuku string) []model.JavaneseDate {
+	var results []model.JavaneseDate
+
+	first, last := s.dayRange(year, month)
+	for day := first; day <= last; day++ {
+		jd := s.convertDay(day)
+		if jd.Wuku == wuku {
 			results = append(results, *jd)
 		}
 	}
@@ -61,54 +101,109 @@ func (s *JavaneseCalendarService) FilterByWeton(year int, month int, weton strin
 	return results
 }
 
-func (s *JavaneseCalendarService) ConvertToJavaneseDate(date time.Time) *model.JavaneseDate {
+// FilterByWewaran - filter tanggal berdasarkan anggota siklus hari manapun,
+// misalnya cycle "sadwara" dan value "Tungle"
+func (s *JavaneseCalendarService) FilterByWewaran(year int, month int, cycle string, value string) []model.JavaneseDate {
+	var results []model.JavaneseDate
 
-	dayIndex := int(date.Weekday())
-	dayName := s.dayNames[dayIndex]
+	first, last := s.dayRange(year, month)
+	for day := first; day <= last; day++ {
+		jd := s.convertDay(day)
+		for _, wewaran := range jd.Wewaran {
+			if wewaran.Cycle == cycle && wewaran.Name == value {
+				results = append(results, *jd)
+				break
+			}
+		}
+	}
 
-	epoch := time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC)
-	daysSinceEpoch := int(date.Sub(epoch).Hours() / 24)
+	return results
+}
 
-	pasaranIndex := (daysSinceEpoch + 3) % 5
-	if pasaranIndex < 0 {
-		pasaranIndex += 5
-	}
-	pasaranName := s.pasaranNames[pasaranIndex]
+func (s *JavaneseCalendarService) ConvertToJavaneseDate(date time.Time) *model.JavaneseDate {
+	return s.convertDay(calendar.FromTime(date))
+}
+
+// convertDay menyusun data lengkap satu hari dari Julian Day Number-nya
+func (s *JavaneseCalendarService) convertDay(day calendar.JDN) *model.JavaneseDate {
+	dayIndex := day.Weekday()
+	dayName := calendar.DayNames[dayIndex]
+
+	pasaranIndex := day.Pasaran()
+	pasaranName := calendar.PasaranNames[pasaranIndex]
 
 	weton := dayName + " " + pasaranName
 
-	javaneseYear := date.Year() - 1632
+	neptu := day.Neptu()
 
-	neptu := s.dayNeptu[dayName] + s.pasaranNeptu[pasaranName]
+	wuku := wukuList[day.Wuku()]
 
-	return &model.JavaneseDate{
-		GregorianDate: date.Format("2006-01-02"),
+	javaneseDate := &model.JavaneseDate{
+		GregorianDate: day.String(),
 		Day:           dayName,
 		Pasaran:       pasaranName,
 		Weton:         weton,
-		JavaneseYear:  javaneseYear,
+		Wuku:          wuku.name,
+		WukuIndex:     day.Wuku() + 1,
+		WukuNeptu:     wuku.neptu,
+		WukuDeity:     wuku.deity,
+		WukuTree:      wuku.tree,
+		WukuBird:      wuku.bird,
+		PranataMangsa: pranataMangsaFor(day),
+		Wewaran:       s.wewaranFor(newCycleDay(day, neptu)),
 		DayOfWeek:     dayIndex + 1,
 		PasaranIndex:  pasaranIndex + 1,
 		Neptu:         neptu,
+		Timezone:      s.location.String(),
+		JulianDay:     int(day),
+		Hijri:         s.ConvertToHijri(day, s.hijriMethod),
+	}
+	if day < calendar.GregorianReform || s.system == calendar.SystemJulian {
+		javaneseDate.JulianDate = day.Format(calendar.SystemJulian)
+	}
+
+	// Sebelum reformasi Sultan Agung, Jawa memakai tahun Saka. Tanggal Jawa
+	// tidak dihitung mundur karena kalendernya belum ada; hanya siklus hari
+	// (weton, wuku, wewaran) yang berlaku.
+	if day < calendar.Epoch {
+		javaneseDate.SakaYear = day.SakaYear()
+		javaneseDate.Note = PreEpochNote
+	} else {
+		javanese := calendar.ToJavanese(day, s.kurup)
+		winduYear := calendar.WinduYear(javanese.Year)
+
+		javaneseDate.JavaneseDay = javanese.Day
+		javaneseDate.JavaneseMonth = javanese.Month
+		javaneseDate.JavaneseMonthName = calendar.MonthNames[javanese.Month-1]
+		javaneseDate.JavaneseYear = javanese.Year
+		javaneseDate.JavaneseYearName = calendar.YearNames[winduYear]
+		javaneseDate.JavaneseYearType = javaneseYearType(javanese.Year)
+		javaneseDate.JavaneseYearNeptu = calendar.YearNeptu[winduYear]
+		javaneseDate.Windu = calendar.WinduNames[calendar.Windu(javanese.Year)]
+		javaneseDate.Kurup = s.kurup.NameFor(javanese.Year)
 	}
+	javaneseDate.BadDays = badDayFlags(javaneseDate)
+	javaneseDate.Holidays = s.holidaysFor(day, javaneseDate)
+
+	return javaneseDate
 }
 
 func (s *JavaneseCalendarService) GetDateRange(start, end time.Time) []*model.JavaneseDate {
+	return s.datesBetween(calendar.FromTime(start), calendar.FromTime(end))
+}
+
+func (s *JavaneseCalendarService) datesBetween(first, last calendar.JDN) []*model.JavaneseDate {
 	var dates []*model.JavaneseDate
 
-	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
-		javaneseDate := s.ConvertToJavaneseDate(d)
-		dates = append(dates, javaneseDate)
+	for day := first; day <= last; day++ {
+		dates = append(dates, s.convertDay(day))
 	}
 
 	return dates
 }
 
 func (s *JavaneseCalendarService) GetYearData(year int) *model.YearData {
-	start := time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)
-	end := time.Date(year, 12, 31, 0, 0, 0, 0, time.UTC)
-
-	dates := s.GetDateRange(start, end)
+	dates := s.datesBetween(s.dayRange(year, 0))
 
 	stats := s.calculateYearStats(dates)
 
@@ -121,10 +216,7 @@ func (s *JavaneseCalendarService) GetYearData(year int) *model.YearData {
 }
 
 func (s *JavaneseCalendarService) GetMonthData(year, month int) *model.MonthData {
-	start := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
-	end := start.AddDate(0, 1, -1)
-
-	dates := s.GetDateRange(start, end)
+	dates := s.datesBetween(s.dayRange(year, month))
 
 	return &model.MonthData{
 		Year:      year,
@@ -134,6 +226,115 @@ func (s *JavaneseCalendarService) GetMonthData(year, month int) *model.MonthData
 	}
 }
 
+// GetJavaneseYearData - daftar bulan dalam satu tahun Jawa beserta awal Masehinya
+func (s *JavaneseCalendarService) GetJavaneseYearData(year int) *model.JavaneseYearData {
+	var months []*model.JavaneseMonthInfo
+
+	start := calendar.YearStart(year, s.kurup)
+	for month := 1; month <= 12; month++ {
+		length := calendar.MonthLength(year, month, s.kurup)
+		months = append(months, &model.JavaneseMonthInfo{
+			Month:          month,
+			Name:           calendar.MonthNames[month-1],
+			Length:         length,
+			GregorianStart: start.String(),
+			GregorianEnd:   start.AddDays(length - 1).String(),
+		})
+		start = start.AddDays(length)
+	}
+
+	return &model.JavaneseYearData{
+		Year:      year,
+		YearName:  calendar.YearNames[calendar.WinduYear(year)],
+		YearType:  javaneseYearType(year),
+		YearNeptu: calendar.YearNeptu[calendar.WinduYear(year)],
+		Windu:     calendar.WinduNames[calendar.Windu(year)],
+		Kurup:     s.kurup.NameFor(year),
+		TotalDays: calendar.YearLength(year, s.kurup),
+		Months:    months,
+	}
+}
+
+// ConvertFromJavaneseDate - konversi tanggal Jawa ke tanggal Masehi sesuai kurup service
+func (s *JavaneseCalendarService) ConvertFromJavaneseDate(year, month, day int) (time.Time, error) {
+	if month < 1 || month > 12 {
+		return time.Time{}, fmt.Errorf("bulan %d tidak ada, gunakan 1-12", month)
+	}
+
+	length := calendar.MonthLength(year, month, s.kurup)
+	if day < 1 || day > length {
+		return time.Time{}, fmt.Errorf("bulan %s %d hanya berumur %d hari", calendar.MonthNames[month-1], year, length)
+	}
+
+	converted, err := calendar.FromJavanese(calendar.Date{Year: year, Month: month, Day: day}, s.kurup)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return converted.Time(), nil
+}
+
+// GetPranataMangsa - 12 mangsa yang dimulai dalam satu tahun Masehi,
+// dari Kawolu (Februari) sampai Kapitu (Desember)
+func (s *JavaneseCalendarService) GetPranataMangsa(year int) []*model.PranataMangsa {
+	var mangsas []*model.PranataMangsa
+	for index := 7; index < len(mangsaList); index++ {
+		mangsas = append(mangsas, buildPranataMangsa(year-1, index))
+	}
+	for index := 0; index < 7; index++ {
+		mangsas = append(mangsas, buildPranataMangsa(year, index))
+	}
+	return mangsas
+}
+
+// CompareKurup - daftar tanggal di mana dua petungan kurup menghasilkan tanggal Jawa berbeda
+func (s *JavaneseCalendarService) CompareKurup(start, end time.Time, kurup1, kurup2 Kurup) *model.KurupComparison {
+	first := s.WithKurup(kurup1)
+	second := s.WithKurup(kurup2)
+
+	var differences []*model.KurupDifference
+	totalDays := 0
+	for day := calendar.FromTime(start); day <= calendar.FromTime(end); day++ {
+		totalDays++
+		date1 := first.convertDay(day)
+		date2 := second.convertDay(day)
+		if date1.JavaneseDay == date2.JavaneseDay &&
+			date1.JavaneseMonth == date2.JavaneseMonth &&
+			date1.JavaneseYear == date2.JavaneseYear {
+			continue
+		}
+		differences = append(differences, &model.KurupDifference{
+			GregorianDate: date1.GregorianDate,
+			Weton:         date1.Weton,
+			Date1:         formatJavaneseDate(date1),
+			Date2:         formatJavaneseDate(date2),
+		})
+	}
+
+	return &model.KurupComparison{
+		Kurup1:        first.kurupLabel(),
+		Kurup2:        second.kurupLabel(),
+		TotalDays:     totalDays,
+		DifferentDays: len(differences),
+		Differences:   differences,
+	}
+}
+
+func (s *JavaneseCalendarService) kurupLabel() string {
+	if s.kurup == KurupHistoris {
+		return "Historis"
+	}
+	return s.kurup.NameFor(0)
+}
+
+// formatJavaneseDate menulis tanggal Jawa, atau tahun Saka untuk hari
+// sebelum kalender Sultan Agung berlaku
+func formatJavaneseDate(date *model.JavaneseDate) string {
+	if date.JavaneseYear == 0 {
+		return fmt.Sprintf("tahun Saka %d", date.SakaYear)
+	}
+	return fmt.Sprintf("%d %s %d", date.JavaneseDay, date.JavaneseMonthName, date.JavaneseYear)
+}
+
 func (s *JavaneseCalendarService) GetWetonByDate(date time.Time) string {
 	javaneseDate := s.ConvertToJavaneseDate(date)
 	return javaneseDate.Weton
@@ -144,9 +345,39 @@ func (s *JavaneseCalendarService) GetNeptuByDate(date time.Time) int {
 	return javaneseDate.Neptu
 }
 
-func (s *JavaneseCalendarService) CalculateWetonCompatibility(weton1, weton2 string) string {
+// CalculateWetonCompatibility menghitung kecocokan weton dengan petungan
+// sisa 8, sisa 7 dan sangan (sisa 9). Kesimpulan utama memakai sisa 8.
+func (s *JavaneseCalendarService) CalculateWetonCompatibility(weton1, weton2 string) *model.WetonCompatibility {
+	neptu1 := s.wetonNeptu(weton1)
+	neptu2 := s.wetonNeptu(weton2)
+	total := neptu1 + neptu2
+
+	remainder8 := remainderOf(total, 8)
+	remainder7 := remainderOf(total, 7)
 
-	return "Implementasi kecocokan weton belum lengkap"
+	sangan1 := remainderOf(neptu1, 9)
+	sangan2 := remainderOf(neptu2, 9)
+	if sangan1 > sangan2 {
+		sangan1, sangan2 = sangan2, sangan1
+	}
+	sanganKey := fmt.Sprintf("%d-%d", sangan1, sangan2)
+
+	methods := []*model.CompatibilityMethod{
+		newCompatibilityMethod("sisa_8", compatibilityMod8[total%8], remainder8),
+		newCompatibilityMethod("sisa_7", compatibilityMod7[total%7], remainder7),
+		newCompatibilityMethod("sangan", compatibilityMod9[sanganKey], remainderOf(neptu1, 9), remainderOf(neptu2, 9)),
+	}
+
+	return &model.WetonCompatibility{
+		Weton1:        weton1,
+		Weton2:        weton2,
+		Neptu1:        neptu1,
+		Neptu2:        neptu2,
+		TotalNeptu:    total,
+		Compatibility: methods[0].Verdict,
+		Description:   methods[0].Description,
+		Methods:       methods,
+	}
 }
 
 func (s *JavaneseCalendarService) calculateYearStats(dates []*model.JavaneseDate) *model.YearStatistics {
@@ -167,38 +398,90 @@ func (s *JavaneseCalendarService) calculateYearStats(dates []*model.JavaneseDate
 	}
 }
 
-func (s *JavaneseCalendarService) GetGoodDays(birthDate time.Time, targetYear int) []time.Time {
-	var goodDays []time.Time
+// GetGoodDays mencari hari baik dalam setahun untuk tujuan tertentu
+// (umum, nikah, pindah-rumah, buka-usaha, tanam, khitan, bepergian)
+func (s *JavaneseCalendarService) GetGoodDays(birthDate time.Time, targetYear int, purpose string) ([]*model.GoodDay, error) {
+	ruleSet, err := findGoodDayRuleSet(purpose)
+	if err != nil {
+		return nil, err
+	}
+
+	var goodDays []*model.GoodDay
 	birthWeton := s.ConvertToJavaneseDate(birthDate)
 
-	start := time.Date(targetYear, 1, 1, 0, 0, 0, 0, time.UTC)
-	end := time.Date(targetYear, 12, 31, 0, 0, 0, 0, time.UTC)
+	first, last := s.dayRange(targetYear, 0)
+	for _, day := range s.goodDayCandidates(first, last, ruleSet, birthWeton) {
+		currentWeton := s.convertDay(day)
 
-	for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
-		currentWeton := s.ConvertToJavaneseDate(d)
+		passedRules, ok := ruleSet.evaluate(goodDayContext{date: currentWeton, birth: birthWeton})
+		if !ok {
+			continue
+		}
+
+		goodDays = append(goodDays, &model.GoodDay{
+			GregorianDate: currentWeton.GregorianDate,
+			Weton:         currentWeton.Weton,
+			JavaneseDate:  formatJavaneseDate(currentWeton),
+			PassedRules:   passedRules,
+		})
+	}
 
-		if currentWeton.Neptu == birthWeton.Neptu ||
-			(currentWeton.Neptu+birthWeton.Neptu)%5 == 0 {
-			goodDays = append(goodDays, d)
+	return goodDays, nil
+}
+
+// goodDayCandidates - aturan yang hanya bergantung pada weton cukup diperiksa
+// sekali untuk tiap weton dalam siklus 35 hari; hari yang wetonnya gagal
+// dilewati tanpa dihitung detailnya
+func (s *JavaneseCalendarService) goodDayCandidates(first, last calendar.JDN, ruleSet goodDayRuleSet, birth *model.JavaneseDate) []calendar.JDN {
+	var candidates []calendar.JDN
+	for day := first; day < first+calendar.WetonCycle && day <= last; day++ {
+		if !ruleSet.wetonPasses(goodDayContext{date: s.wetonOf(day), birth: birth}) {
+			continue
+		}
+		for candidate := day; candidate <= last; candidate += calendar.WetonCycle {
+			candidates = append(candidates, candidate)
 		}
 	}
 
-	return goodDays
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i] < candidates[j]
+	})
+	return candidates
+}
+
+// wetonOf hanya mengisi hari, pasaran, weton dan neptu
+func (s *JavaneseCalendarService) wetonOf(day calendar.JDN) *model.JavaneseDate {
+	dayName := calendar.DayNames[day.Weekday()]
+	pasaranName := calendar.PasaranNames[day.Pasaran()]
+	return &model.JavaneseDate{
+		Day:          dayName,
+		Pasaran:      pasaranName,
+		Weton:        dayName + " " + pasaranName,
+		DayOfWeek:    day.Weekday() + 1,
+		PasaranIndex: day.Pasaran() + 1,
+		Neptu:        day.Neptu(),
+	}
 }
 
 func (s *JavaneseCalendarService) GetDayNeptu(day string) int {
-	return s.dayNeptu[day]
+	if i := indexOf(calendar.DayNames, day); i >= 0 {
+		return calendar.DayNeptu[i]
+	}
+	return 0
 }
 
 func (s *JavaneseCalendarService) GetPasaranNeptu(pasaran string) int {
-	return s.pasaranNeptu[pasaran]
+	if i := indexOf(calendar.PasaranNames, pasaran); i >= 0 {
+		return calendar.PasaranNeptu[i]
+	}
+	return 0
 }
 
 // Method baru untuk mendapatkan semua kemungkinan weton
 func (s *JavaneseCalendarService) GetAllPossibleWeton() []string {
 	var wetons []string
-	for _, day := range s.dayNames {
-		for _, pasaran := range s.pasaranNames {
+	for _, day := range calendar.DayNames {
+		for _, pasaran := range calendar.PasaranNames {
 			wetons = append(wetons, day+" "+pasaran)
 		}
 	}
@@ -212,41 +495,20 @@ func (s *JavaneseCalendarService) IsValidWeton(weton string) bool {
 		return false
 	}
 
-	day := parts[0]
-	pasaran := parts[1]
-
-	// Validasi hari
-	validDay := false
-	for _, d := range s.dayNames {
-		if strings.EqualFold(d, day) {
-			validDay = true
-			break
-		}
-	}
-
-	// Validasi pasaran
-	validPasaran := false
-	for _, p := range s.pasaranNames {
-		if strings.EqualFold(p, pasaran) {
-			validPasaran = true
-			break
-		}
-	}
-
-	return validDay && validPasaran
+	return indexOf(calendar.DayNames, parts[0]) >= 0 && indexOf(calendar.PasaranNames, parts[1]) >= 0
 }
 
 // Method untuk mencari weton berikutnya dari tanggal tertentu
 func (s *JavaneseCalendarService) FindNextWetonOccurrence(startDate time.Time, targetWeton string) *time.Time {
-	// Maksimal cari 35 hari ke depan (1 siklus lengkap weton)
-	for i := 0; i < 35; i++ {
-		checkDate := startDate.AddDate(0, 0, i)
-		jd := s.ConvertToJavaneseDate(checkDate)
-		if strings.EqualFold(jd.Weton, targetWeton) {
-			return &checkDate
-		}
+	dayIndex, pasaranIndex, ok := s.parseWeton(targetWeton)
+	if !ok {
+		return nil
 	}
-	return nil
+
+	// Weton berulang tiap 35 hari, jadi selisihnya bisa dihitung langsung
+	from := calendar.FromTime(startDate)
+	next := startDate.AddDate(0, 0, calendar.NextWeton(from, dayIndex, pasaranIndex).Sub(from))
+	return &next
 }
 
 // Method untuk menghitung berapa hari lagi sampai weton tertentu
@@ -255,5 +517,5 @@ func (s *JavaneseCalendarService) DaysUntilWeton(fromDate time.Time, targetWeton
 	if nextOccurrence == nil {
 		return -1
 	}
-	return int(nextOccurrence.Sub(fromDate).Hours() / 24)
+	return calendar.FromTime(*nextOccurrence).Sub(calendar.FromTime(fromDate))
 }
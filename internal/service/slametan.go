@@ -0,0 +1,119 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/yuxxeun/jakal/internal/model"
+	"github.com/yuxxeun/jakal/pkg/calendar"
+)
+
+type slametanData struct {
+	name   string
+	offset int // selisih hari dari geblag, 0 untuk peringatan berbasis tahun Jawa
+	years  int // jumlah tahun Jawa untuk mendhak
+	// Rumus hanya dicantumkan untuk peringatan dengan selisih hari tetap.
+	// Rumus mendhak (Patsarpat, Jisarlu) menghitung 354 dan 708 hari,
+	// sedangkan mendhak di sini mengikuti tanggal Jawa geblag.
+	rumus string
+	dina  int // urutan hari menurut rumus, dihitung mulai dari hari geblag
+	pasar int // urutan pasaran menurut rumus
+}
+
+var slametanList = []slametanData{
+	{name: "Geblag", offset: 0},
+	{name: "Nelung Dina", offset: 2, rumus: "Lusarlu", dina: 3, pasar: 3},
+	{name: "Mitung Dina", offset: 6, rumus: "Tusaro", dina: 7, pasar: 2},
+	{name: "Matang Puluh", offset: 39, rumus: "Masarma", dina: 5, pasar: 5},
+	{name: "Nyatus", offset: 99, rumus: "Rosarma", dina: 2, pasar: 5},
+	{name: "Mendhak Pisan", years: 1},
+	{name: "Mendhak Pindho", years: 2},
+	{name: "Nyewu", offset: 999, rumus: "Nemsarma", dina: 6, pasar: 5},
+}
+
+// GetSlametan menghitung tanggal peringatan kematian. Bila shift menunjukkan
+// kematian setelah maghrib, geblag dihitung sebagai hari berikutnya.
+// Peringatan yang jatuh setelah tahun MaxYear dilewati dan disebut di note.
+func (s *JavaneseCalendarService) GetSlametan(deathDate time.Time, shift *model.SunsetShift) (*model.SlametanResponse, error) {
+	afterSunset := shift != nil && shift.Applied
+	geblagDay := calendar.FromTime(deathDate)
+	if afterSunset {
+		geblagDay++
+	}
+	if geblagDay > maxDay {
+		return nil, fmt.Errorf("geblag jatuh setelah tahun %d", MaxYear)
+	}
+	geblag := s.convertDay(geblagDay)
+
+	var commemorations []*model.Slametan
+	var mendhak, beforeEpoch, afterMaxYear []string
+	for _, data := range slametanList {
+		day := geblagDay.AddDays(data.offset)
+		if data.years > 0 {
+			// Mendhak mengikuti tanggal Jawa geblag, yang belum ada sebelum 1633
+			if geblag.JavaneseYear == 0 {
+				beforeEpoch = append(beforeEpoch, data.name)
+				continue
+			}
+			day = s.javaneseAnniversary(geblag, data.years)
+			mendhak = append(mendhak, data.name)
+		}
+		if day > maxDay {
+			afterMaxYear = append(afterMaxYear, data.name)
+			continue
+		}
+		jd := s.convertDay(day)
+
+		slametan := &model.Slametan{
+			Name:          data.name,
+			Day:           day.Sub(geblagDay) + 1,
+			GregorianDate: jd.GregorianDate,
+			Weton:         jd.Weton,
+			JavaneseDate:  formatJavaneseDate(jd),
+		}
+
+		if data.rumus != "" {
+			rumusWeton := calendar.DayNames[(geblag.DayOfWeek-1+data.dina-1)%7] + " " +
+				calendar.PasaranNames[(geblag.PasaranIndex-1+data.pasar-1)%5]
+			slametan.Rumus = data.rumus
+			slametan.RumusWeton = rumusWeton
+			matches := rumusWeton == jd.Weton
+			slametan.RumusMatches = &matches
+		}
+
+		commemorations = append(commemorations, slametan)
+	}
+
+	response := &model.SlametanResponse{
+		DateOfDeath:    deathDate.Format("2006-01-02"),
+		AfterSunset:    afterSunset,
+		SunsetShift:    shift,
+		GeblagDate:     geblag.GregorianDate,
+		GeblagWeton:    geblag.Weton,
+		Commemorations: commemorations,
+	}
+	var notes []string
+	if len(mendhak) > 0 {
+		notes = append(notes, strings.Join(mendhak, ", ")+" mengikuti tanggal dan bulan Jawa geblag satu dan dua tahun kemudian, bukan hitungan hari rumus Patsarpat (354 hari) dan Jisarlu (708 hari), sehingga rumusnya tidak dicantumkan")
+	}
+	if len(beforeEpoch) > 0 {
+		notes = append(notes, strings.Join(beforeEpoch, ", ")+" tidak dihitung karena geblag jatuh sebelum kalender Jawa Sultan Agung berlaku (8 Juli 1633)")
+	}
+	if len(afterMaxYear) > 0 {
+		notes = append(notes, fmt.Sprintf("%s jatuh setelah tahun %d sehingga tidak dihitung", strings.Join(afterMaxYear, ", "), MaxYear))
+	}
+	response.Note = strings.Join(notes, "; ")
+	return response, nil
+}
+
+// javaneseAnniversary - tanggal dan bulan Jawa yang sama beberapa tahun
+// kemudian; tanggal 30 bergeser ke 29 bila bulannya hanya 29 hari
+func (s *JavaneseCalendarService) javaneseAnniversary(jd *model.JavaneseDate, years int) calendar.JDN {
+	date := calendar.Date{Year: jd.JavaneseYear + years, Month: jd.JavaneseMonth, Day: jd.JavaneseDay}
+	if length := calendar.MonthLength(date.Year, date.Month, s.kurup); date.Day > length {
+		date.Day = length
+	}
+	day, _ := calendar.FromJavanese(date, s.kurup)
+	return day
+}
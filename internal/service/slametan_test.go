@@ -0,0 +1,43 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// Rumus yang dicantumkan harus cocok dengan weton peringatannya; mendhak
+// mengikuti tanggal Jawa sehingga tidak membawa rumus
+func TestSlametanRumus(t *testing.T) {
+	s := NewJavaneseCalendarService()
+	for _, date := range []string{"1936-03-24", "2000-02-29", "2024-01-10", "2025-06-26"} {
+		deathDate, _ := time.Parse("2006-01-02", date)
+		response, err := s.GetSlametan(deathDate, nil)
+		if err != nil {
+			t.Fatalf("GetSlametan(%s): %v", date, err)
+		}
+
+		for _, commemoration := range response.Commemorations {
+			mendhak := strings.HasPrefix(commemoration.Name, "Mendhak")
+			switch {
+			case mendhak && (commemoration.Rumus != "" || commemoration.RumusMatches != nil):
+				t.Errorf("%s, %s: rumus %q dicantumkan", date, commemoration.Name, commemoration.Rumus)
+			case !mendhak && commemoration.RumusMatches != nil && !*commemoration.RumusMatches:
+				t.Errorf("%s, %s: rumus %s memberi %s, peringatan jatuh %s",
+					date, commemoration.Name, commemoration.Rumus, commemoration.RumusWeton, commemoration.Weton)
+			}
+			// Tanggal dan bulan Jawa sama dengan geblag, hanya tahunnya berbeda
+			geblag := response.Commemorations[0].JavaneseDate
+			if mendhak && dayAndMonth(commemoration.JavaneseDate) != dayAndMonth(geblag) {
+				t.Errorf("%s, %s jatuh %s, geblag %s", date, commemoration.Name, commemoration.JavaneseDate, geblag)
+			}
+		}
+		if !strings.Contains(response.Note, "Mendhak Pisan, Mendhak Pindho mengikuti tanggal dan bulan Jawa geblag") {
+			t.Errorf("%s: note %q tidak menjelaskan mendhak", date, response.Note)
+		}
+	}
+}
+
+func dayAndMonth(javaneseDate string) string {
+	return javaneseDate[:strings.LastIndex(javaneseDate, " ")]
+}
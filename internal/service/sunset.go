@@ -0,0 +1,121 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/yuxxeun/jakal/internal/model"
+)
+
+// Location - titik pengamatan untuk menghitung waktu maghrib
+type Location struct {
+	Name      string
+	Latitude  float64
+	Longitude float64
+	UTCOffset int // jam, WIB = 7
+}
+
+// Tanpa lokasi, maghrib dihitung untuk Yogyakarta sebagai pusat penanggalan Jawa
+var DefaultLocation = cities["yogyakarta"]
+
+var cities = map[string]Location{
+	"jakarta":    {Name: "Jakarta", Latitude: -6.2088, Longitude: 106.8456, UTCOffset: 7},
+	"bandung":    {Name: "Bandung", Latitude: -6.9175, Longitude: 107.6191, UTCOffset: 7},
+	"cirebon":    {Name: "Cirebon", Latitude: -6.7320, Longitude: 108.5523, UTCOffset: 7},
+	"tegal":      {Name: "Tegal", Latitude: -6.8694, Longitude: 109.1402, UTCOffset: 7},
+	"purwokerto": {Name: "Purwokerto", Latitude: -7.4245, Longitude: 109.2302, UTCOffset: 7},
+	"semarang":   {Name: "Semarang", Latitude: -6.9667, Longitude: 110.4167, UTCOffset: 7},
+	"magelang":   {Name: "Magelang", Latitude: -7.4797, Longitude: 110.2177, UTCOffset: 7},
+	"yogyakarta": {Name: "Yogyakarta", Latitude: -7.7956, Longitude: 110.3695, UTCOffset: 7},
+	"surakarta":  {Name: "Surakarta", Latitude: -7.5755, Longitude: 110.8243, UTCOffset: 7},
+	"madiun":     {Name: "Madiun", Latitude: -7.6298, Longitude: 111.5239, UTCOffset: 7},
+	"kediri":     {Name: "Kediri", Latitude: -7.8480, Longitude: 112.0178, UTCOffset: 7},
+	"malang":     {Name: "Malang", Latitude: -7.9666, Longitude: 112.6326, UTCOffset: 7},
+	"surabaya":   {Name: "Surabaya", Latitude: -7.2575, Longitude: 112.7521, UTCOffset: 7},
+	"banyuwangi": {Name: "Banyuwangi", Latitude: -8.2192, Longitude: 114.3691, UTCOffset: 7},
+	"denpasar":   {Name: "Denpasar", Latitude: -8.6705, Longitude: 115.2126, UTCOffset: 8},
+	"medan":      {Name: "Medan", Latitude: 3.5952, Longitude: 98.6722, UTCOffset: 7},
+	"palembang":  {Name: "Palembang", Latitude: -2.9761, Longitude: 104.7754, UTCOffset: 7},
+	"makassar":   {Name: "Makassar", Latitude: -5.1477, Longitude: 119.4327, UTCOffset: 8},
+	"jayapura":   {Name: "Jayapura", Latitude: -2.5337, Longitude: 140.7181, UTCOffset: 9},
+}
+
+var cityAliases = map[string]string{
+	"jogja":      "yogyakarta",
+	"jogjakarta": "yogyakarta",
+	"solo":       "surakarta",
+}
+
+// FindCity mencari lokasi kota yang dikenal, misalnya "solo" atau "yogyakarta"
+func FindCity(name string) (Location, bool) {
+	key := strings.ToLower(strings.TrimSpace(name))
+	if alias, ok := cityAliases[key]; ok {
+		key = alias
+	}
+	loc, ok := cities[key]
+	return loc, ok
+}
+
+// NewLocation membuat lokasi dari koordinat; zona waktu diperkirakan dari bujur
+func NewLocation(latitude, longitude float64) (Location, error) {
+	if latitude < -90 || latitude > 90 || longitude < -180 || longitude > 180 {
+		return Location{}, fmt.Errorf("koordinat di luar jangkauan")
+	}
+	return Location{
+		Name:      fmt.Sprintf("%.4f,%.4f", latitude, longitude),
+		Latitude:  latitude,
+		Longitude: longitude,
+		UTCOffset: int(math.Round(longitude / 15)),
+	}, nil
+}
+
+// sunsetMinutes menghitung waktu terbenam matahari (menit sejak tengah malam,
+// waktu setempat) dengan persamaan NOAA. Ketelitiannya sekitar satu menit
+// untuk lintang tropis.
+func sunsetMinutes(date time.Time, loc Location) float64 {
+	gamma := 2 * math.Pi / 365 * float64(date.YearDay()-1)
+
+	eqTime := 229.18 * (0.000075 + 0.001868*math.Cos(gamma) - 0.032077*math.Sin(gamma) -
+		0.014615*math.Cos(2*gamma) - 0.040849*math.Sin(2*gamma))
+	decl := 0.006918 - 0.399912*math.Cos(gamma) + 0.070257*math.Sin(gamma) -
+		0.006758*math.Cos(2*gamma) + 0.000907*math.Sin(2*gamma) -
+		0.002697*math.Cos(3*gamma) + 0.00148*math.Sin(3*gamma)
+
+	lat := loc.Latitude * math.Pi / 180
+	zenith := 90.833 * math.Pi / 180
+	cosHA := math.Cos(zenith)/(math.Cos(lat)*math.Cos(decl)) - math.Tan(lat)*math.Tan(decl)
+	// Di lintang kutub matahari bisa tidak terbenam; batasi agar tetap ada jawaban
+	cosHA = math.Max(-1, math.Min(1, cosHA))
+	hourAngle := math.Acos(cosHA) * 180 / math.Pi
+
+	utc := 720 - 4*(loc.Longitude-hourAngle) - eqTime
+	return utc + float64(loc.UTCOffset*60)
+}
+
+// ApplySunsetBoundary - hari Jawa berganti saat maghrib, sehingga kelahiran
+// Kamis pukul 19.00 terhitung malam Jumat. Mengembalikan tanggal Masehi yang
+// hari Jawanya berlaku beserta keterangan pergeserannya.
+func ApplySunsetBoundary(date time.Time, clock time.Duration, loc Location) (time.Time, *model.SunsetShift) {
+	sunset := sunsetMinutes(date, loc)
+	minutes := int(math.Round(sunset))
+
+	shift := &model.SunsetShift{
+		Time:      fmt.Sprintf("%02d:%02d", int(clock.Hours()), int(clock.Minutes())%60),
+		Sunset:    fmt.Sprintf("%02d:%02d", minutes/60, minutes%60),
+		Location:  loc.Name,
+		Latitude:  loc.Latitude,
+		Longitude: loc.Longitude,
+		UTCOffset: loc.UTCOffset,
+	}
+
+	effective := date
+	if clock.Minutes() >= sunset {
+		effective = date.AddDate(0, 0, 1)
+		shift.Applied = true
+	}
+	shift.EffectiveDate = effective.Format("2006-01-02")
+
+	return effective, shift
+}
@@ -0,0 +1,62 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	// Vercel tidak menyediakan zoneinfo, jadi database zona waktu ikut dikompilasi
+	_ "time/tzdata"
+)
+
+// DefaultTimezone - pengguna utama berada di Jawa (WIB)
+const DefaultTimezone = "Asia/Jakarta"
+
+var timezoneAliases = map[string]string{
+	"wib":  "Asia/Jakarta",
+	"wita": "Asia/Makassar",
+	"wit":  "Asia/Jayapura",
+}
+
+// ParseTimezone menerima singkatan WIB, WITA, WIT atau nama zona IANA
+// seperti "Asia/Makassar". Nilai kosong berarti DefaultTimezone.
+func ParseTimezone(name string) (*time.Location, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = DefaultTimezone
+	}
+	if zone, ok := timezoneAliases[strings.ToLower(name)]; ok {
+		name = zone
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("zona waktu %q tidak dikenal", name)
+	}
+	return loc, nil
+}
+
+func mustLoadLocation(name string) *time.Location {
+	loc, err := ParseTimezone(name)
+	if err != nil {
+		panic(err)
+	}
+	return loc
+}
+
+// WithLocation mengembalikan salinan service yang memakai zona waktu tertentu
+// untuk menentukan "hari ini"
+func (s *JavaneseCalendarService) WithLocation(loc *time.Location) *JavaneseCalendarService {
+	clone := *s
+	clone.location = loc
+	return &clone
+}
+
+// Now - waktu sekarang di zona waktu service, bukan zona waktu server
+func (s *JavaneseCalendarService) Now() time.Time {
+	return time.Now().In(s.location)
+}
+
+// Location - zona waktu yang dipakai service
+func (s *JavaneseCalendarService) Location() *time.Location {
+	return s.location
+}
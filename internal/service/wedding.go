@@ -0,0 +1,130 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/yuxxeun/jakal/internal/model"
+)
+
+// Bobot hitungan Sri, Lungguh, Gedhong, Lara terhadap weton masing-masing
+// calon; Pati tidak pernah lolos karena termasuk naas pribadi
+var weddingCountPoints = map[string]int{
+	"Sri":     3,
+	"Lungguh": 2,
+	"Gedhong": 3,
+	"Lara":    -2,
+}
+
+// Bulan yang dianggap baik atau kurang baik untuk pernikahan
+var weddingMonthPoints = map[string]int{
+	"Besar": 2,
+	"Rejeb": 1,
+	"Sela":  -1,
+}
+
+var weddingForbiddenMonths = forbiddenMonthRule("Sura", "Pasa")
+
+// GetWeddingDates menilai setiap hari dalam setahun untuk pernikahan kedua
+// calon, lalu mengurutkan dari skor tertinggi. excludedMonths berisi bulan
+// Jawa (1-12) yang menjadi larangan keluarga di luar Sura dan Pasa.
+func (s *JavaneseCalendarService) GetWeddingDates(brideBirth, groomBirth time.Time, year int, excludedMonths []int) *model.WeddingDatesResponse {
+	bride := s.ConvertToJavaneseDate(brideBirth)
+	groom := s.ConvertToJavaneseDate(groomBirth)
+
+	var dates []*model.WeddingDate
+
+	first, last := s.dayRange(year, 0)
+	for day := first; day <= last; day++ {
+		jd := s.convertDay(day)
+		if containsInt(excludedMonths, jd.JavaneseMonth) {
+			continue
+		}
+		if candidate := scoreWeddingDate(jd, bride, groom); candidate != nil {
+			dates = append(dates, candidate)
+		}
+	}
+
+	sort.SliceStable(dates, func(i, j int) bool {
+		return dates[i].Score > dates[j].Score
+	})
+	for i, date := range dates {
+		date.Rank = i + 1
+	}
+
+	return &model.WeddingDatesResponse{
+		BrideWeton:    bride.Weton,
+		GroomWeton:    groom.Weton,
+		Year:          year,
+		Compatibility: s.CalculateWetonCompatibility(bride.Weton, groom.Weton),
+		TotalDates:    len(dates),
+		Dates:         dates,
+	}
+}
+
+// scoreWeddingDate mengembalikan nil untuk tanggal yang dilarang
+func scoreWeddingDate(jd, bride, groom *model.JavaneseDate) *model.WeddingDate {
+	if !weddingForbiddenMonths.check(goodDayContext{date: jd}) || len(jd.BadDays) > 0 {
+		return nil
+	}
+	if jd.Weton == bride.Weton || jd.Weton == groom.Weton {
+		return nil
+	}
+
+	var reasons []*model.ScoreReason
+	for _, partner := range []struct {
+		label string
+		birth *model.JavaneseDate
+	}{{"pengantin putri", bride}, {"pengantin putra", groom}} {
+		count := sriLungguhGedhong[(jd.Neptu+partner.birth.Neptu)%5]
+		if count == "Pati" {
+			return nil
+		}
+		reasons = append(reasons, &model.ScoreReason{
+			Points: weddingCountPoints[count],
+			Reason: fmt.Sprintf("Neptu hari dengan weton %s (%s) jatuh pada %s", partner.label, partner.birth.Weton, count),
+		})
+	}
+
+	// Petung tiga neptu: neptu kedua calon ditambah neptu hari, sisa 8
+	total := bride.Neptu + groom.Neptu + jd.Neptu
+	verdict := compatibilityMod8[total%8]
+	points := -2
+	if verdict.favorable {
+		points = 3
+	}
+	reasons = append(reasons, &model.ScoreReason{
+		Points: points,
+		Reason: fmt.Sprintf("Jumlah neptu kedua calon dan hari (%d) jatuh pada %s", total, verdict.verdict),
+	})
+
+	if points, ok := weddingMonthPoints[jd.JavaneseMonthName]; ok {
+		reasons = append(reasons, &model.ScoreReason{
+			Points: points,
+			Reason: "Bulan " + jd.JavaneseMonthName,
+		})
+	}
+
+	score := 0
+	for _, reason := range reasons {
+		score += reason.Points
+	}
+
+	return &model.WeddingDate{
+		GregorianDate: jd.GregorianDate,
+		Weton:         jd.Weton,
+		JavaneseDate:  formatJavaneseDate(jd),
+		Score:         score,
+		Reasons:       reasons,
+	}
+}
+
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
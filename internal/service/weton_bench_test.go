@@ -0,0 +1,111 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/yuxxeun/jakal/internal/model"
+	"github.com/yuxxeun/jakal/pkg/calendar"
+)
+
+// Pembanding: cara lama yang menghitung setiap hari satu per satu
+
+func scanWetonDays(s *JavaneseCalendarService, first, last calendar.JDN, weton string) []model.JavaneseDate {
+	var results []model.JavaneseDate
+	for day := first; day <= last; day++ {
+		jd := s.convertDay(day)
+		if jd.Weton == weton {
+			results = append(results, *jd)
+		}
+	}
+	return results
+}
+
+func scanGoodDays(s *JavaneseCalendarService, birthDate time.Time, year int, purpose string) []*model.GoodDay {
+	ruleSet, _ := findGoodDayRuleSet(purpose)
+	birth := s.ConvertToJavaneseDate(birthDate)
+
+	var goodDays []*model.GoodDay
+	first, last := s.dayRange(year, 0)
+	for day := first; day <= last; day++ {
+		jd := s.convertDay(day)
+		if passed, ok := ruleSet.evaluate(goodDayContext{date: jd, birth: birth}); ok {
+			goodDays = append(goodDays, &model.GoodDay{GregorianDate: jd.GregorianDate, PassedRules: passed})
+		}
+	}
+	return goodDays
+}
+
+func scanNextWeton(s *JavaneseCalendarService, startDate time.Time, weton string) *time.Time {
+	for i := 0; i < 35; i++ {
+		checkDate := startDate.AddDate(0, 0, i)
+		if s.ConvertToJavaneseDate(checkDate).Weton == weton {
+			return &checkDate
+		}
+	}
+	return nil
+}
+
+var (
+	bench1900  = time.Date(1900, time.January, 1, 0, 0, 0, 0, time.UTC)
+	bench2100  = time.Date(2100, time.December, 31, 0, 0, 0, 0, time.UTC)
+	benchBirth = time.Date(1990, time.May, 15, 0, 0, 0, 0, time.UTC)
+)
+
+func BenchmarkFilterByWetonYear(b *testing.B) {
+	s := NewJavaneseCalendarService()
+	for i := 0; i < b.N; i++ {
+		s.FilterByWeton(2025, 0, "Jumat Kliwon")
+	}
+}
+
+func BenchmarkFilterByWetonYearScan(b *testing.B) {
+	s := NewJavaneseCalendarService()
+	first, last := s.dayRange(2025, 0)
+	for i := 0; i < b.N; i++ {
+		scanWetonDays(s, first, last, "Jumat Kliwon")
+	}
+}
+
+func BenchmarkFilterByWetonRange1900To2100(b *testing.B) {
+	s := NewJavaneseCalendarService()
+	for i := 0; i < b.N; i++ {
+		s.FilterByWetonRange(bench1900, bench2100, "Jumat Kliwon")
+	}
+}
+
+func BenchmarkFilterByWetonRange1900To2100Scan(b *testing.B) {
+	s := NewJavaneseCalendarService()
+	first, last := calendar.FromTime(bench1900), calendar.FromTime(bench2100)
+	for i := 0; i < b.N; i++ {
+		scanWetonDays(s, first, last, "Jumat Kliwon")
+	}
+}
+
+func BenchmarkGetGoodDays(b *testing.B) {
+	s := NewJavaneseCalendarService()
+	for i := 0; i < b.N; i++ {
+		s.GetGoodDays(benchBirth, 2025, PurposePindah)
+	}
+}
+
+func BenchmarkGetGoodDaysScan(b *testing.B) {
+	s := NewJavaneseCalendarService()
+	for i := 0; i < b.N; i++ {
+		scanGoodDays(s, benchBirth, 2025, PurposePindah)
+	}
+}
+
+func BenchmarkFindNextWetonOccurrence(b *testing.B) {
+	s := NewJavaneseCalendarService()
+	for i := 0; i < b.N; i++ {
+		s.FindNextWetonOccurrence(benchBirth, "Sabtu Kliwon")
+	}
+}
+
+func BenchmarkFindNextWetonOccurrenceScan(b *testing.B) {
+	s := NewJavaneseCalendarService()
+	for i := 0; i < b.N; i++ {
+		scanNextWeton(s, benchBirth, "Sabtu Kliwon")
+	}
+}
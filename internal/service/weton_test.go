@@ -0,0 +1,119 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/yuxxeun/jakal/pkg/calendar"
+)
+
+// Jalur cepat (lompat 35 hari) harus memberi hasil yang sama persis dengan
+// pembanding di weton_bench_test.go yang memeriksa setiap hari.
+//
+// Tahun uji mencakup reformasi Gregorian (1582), awal kalender Sultan Agung
+// (1633), awal kurup Amiswon (1749), Aboge (1819), Asapon (1936) dan
+// Anenge (2052), 1 Sura Alip pembuka windu (2013, 2021), tahun kabisat
+// Masehi (2000, 2024) serta batas tahun 1 dan 9999.
+var equivalenceYears = []int{1, 1582, 1633, 1749, 1819, 1900, 1936, 2000, 2013, 2021, 2024, 2052, 9999}
+
+var equivalenceKurups = []Kurup{KurupHistoris, KurupAboge, KurupAsapon}
+
+func TestFilterByWetonMatchesScan(t *testing.T) {
+	base := NewJavaneseCalendarService()
+	for _, kurup := range equivalenceKurups {
+		s := base.WithKurup(kurup)
+		for _, year := range equivalenceYears {
+			for _, weton := range s.GetAllPossibleWeton() {
+				first, last := s.dayRange(year, 0)
+				if got, want := s.FilterByWeton(year, 0, weton), scanWetonDays(s, first, last, weton); !reflect.DeepEqual(got, want) {
+					t.Errorf("kurup %q, %d, %s: FilterByWeton memberi %d tanggal, pembanding %d", kurup, year, weton, len(got), len(want))
+				}
+			}
+		}
+	}
+}
+
+func TestFilterByWetonMonthMatchesScan(t *testing.T) {
+	s := NewJavaneseCalendarService()
+	for _, year := range []int{1582, 2024} {
+		for month := 1; month <= 12; month++ {
+			first, last := s.dayRange(year, month)
+			for _, weton := range []string{"Minggu Legi", "Jumat Kliwon", "Sabtu Wage"} {
+				if got, want := s.FilterByWeton(year, month, weton), scanWetonDays(s, first, last, weton); !reflect.DeepEqual(got, want) {
+					t.Errorf("%d-%02d, %s: FilterByWeton memberi %d tanggal, pembanding %d", year, month, weton, len(got), len(want))
+				}
+			}
+		}
+	}
+}
+
+func TestFilterByWetonRangeMatchesScan(t *testing.T) {
+	s := NewJavaneseCalendarService()
+	got := s.FilterByWetonRange(bench1900, bench2100, "Jumat Kliwon")
+	want := scanWetonDays(s, calendar.FromTime(bench1900), calendar.FromTime(bench2100), "Jumat Kliwon")
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FilterByWetonRange 1900-2100 memberi %d tanggal, pembanding %d", len(got), len(want))
+	}
+}
+
+func TestGetGoodDaysMatchesScan(t *testing.T) {
+	purposes := []string{PurposeUmum, PurposeNikah, PurposePindah, PurposeBukaUsaha, PurposeTanam, PurposeKhitan, PurposeBepergian}
+	births := []time.Time{
+		benchBirth,
+		time.Date(1936, time.March, 24, 0, 0, 0, 0, time.UTC),
+	}
+
+	base := NewJavaneseCalendarService()
+	for _, kurup := range equivalenceKurups {
+		s := base.WithKurup(kurup)
+		for _, year := range equivalenceYears {
+			for _, birth := range births {
+				for _, purpose := range purposes {
+					goodDays, err := s.GetGoodDays(birth, year, purpose)
+					if err != nil {
+						t.Fatalf("GetGoodDays(%s): %v", purpose, err)
+					}
+					want := scanGoodDays(s, birth, year, purpose)
+
+					if len(goodDays) != len(want) {
+						t.Errorf("kurup %q, %d, lahir %s, %s: GetGoodDays memberi %d hari, pembanding %d",
+							kurup, year, birth.Format("2006-01-02"), purpose, len(goodDays), len(want))
+						continue
+					}
+					for i := range goodDays {
+						if goodDays[i].GregorianDate != want[i].GregorianDate || !reflect.DeepEqual(goodDays[i].PassedRules, want[i].PassedRules) {
+							t.Errorf("kurup %q, %d, %s: hari ke-%d %s, pembanding %s",
+								kurup, year, purpose, i, goodDays[i].GregorianDate, want[i].GregorianDate)
+							break
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestFindNextWetonOccurrenceMatchesScan(t *testing.T) {
+	s := NewJavaneseCalendarService()
+	starts := []time.Time{
+		time.Date(1, time.January, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(1582, time.October, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(1633, time.July, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, time.February, 26, 0, 0, 0, 0, time.UTC),
+		time.Date(9999, time.November, 20, 0, 0, 0, 0, time.UTC),
+	}
+
+	for _, start := range starts {
+		// Setiap posisi dalam satu siklus weton sebagai titik awal
+		for offset := 0; offset < calendar.WetonCycle; offset++ {
+			from := start.AddDate(0, 0, offset)
+			for _, weton := range s.GetAllPossibleWeton() {
+				got, want := s.FindNextWetonOccurrence(from, weton), scanNextWeton(s, from, weton)
+				if got == nil || want == nil || !got.Equal(*want) {
+					t.Fatalf("FindNextWetonOccurrence(%s, %s) = %v, pembanding %v", from.Format("2006-01-02"), weton, got, want)
+				}
+			}
+		}
+	}
+}
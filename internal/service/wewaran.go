@@ -0,0 +1,158 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/yuxxeun/jakal/internal/model"
+	"github.com/yuxxeun/jakal/pkg/calendar"
+)
+
+// cycleDay berisi posisi sebuah tanggal yang dibutuhkan untuk menghitung wewaran
+type cycleDay struct {
+	pawukonDay   int // 0 = Minggu wuku Sinta ... 209 = Sabtu wuku Watugunung
+	dayIndex     int
+	pasaranIndex int
+	neptu        int
+}
+
+// dayCycle adalah satu siklus hari (wewaran) beserta urip tiap anggotanya
+type dayCycle struct {
+	name     string
+	alias    string
+	names    []string
+	urip     []int
+	position func(day cycleDay) int
+}
+
+func newCycleDay(day calendar.JDN, neptu int) cycleDay {
+	return cycleDay{
+		pawukonDay:   day.PawukonDay(),
+		dayIndex:     day.Weekday(),
+		pasaranIndex: day.Pasaran(),
+		neptu:        neptu,
+	}
+}
+
+// kalaTiga - caturwara dan hastawara berhenti di hari ke-70 pawukon selama
+// tiga hari (Jaya tiga / Kala tiga) agar siklusnya genap 210 hari
+func kalaTiga(pawukonDay int) int {
+	switch {
+	case pawukonDay < 71:
+		return pawukonDay
+	case pawukonDay < 73:
+		return 70
+	default:
+		return pawukonDay - 2
+	}
+}
+
+func (s *JavaneseCalendarService) buildDayCycles() []dayCycle {
+	return []dayCycle{
+		{
+			name:     "dwiwara",
+			names:    []string{"Menga", "Pepet"},
+			urip:     []int{5, 7},
+			position: func(d cycleDay) int { return d.neptu % 2 },
+		},
+		{
+			name:     "triwara",
+			names:    []string{"Pasah", "Beteng", "Kajeng"},
+			urip:     []int{9, 4, 7},
+			position: func(d cycleDay) int { return d.pawukonDay % 3 },
+		},
+		{
+			name:     "caturwara",
+			names:    []string{"Sri", "Laba", "Jaya", "Menala"},
+			urip:     []int{6, 5, 1, 8},
+			position: func(d cycleDay) int { return kalaTiga(d.pawukonDay) % 4 },
+		},
+		{
+			name:     "pancawara",
+			alias:    "pasaran",
+			names:    calendar.PasaranNames,
+			urip:     calendar.PasaranNeptu,
+			position: func(d cycleDay) int { return d.pasaranIndex },
+		},
+		{
+			name:     "sadwara",
+			alias:    "paringkelan",
+			names:    []string{"Tungle", "Aryang", "Wurukung", "Paningron", "Uwas", "Mawulu"},
+			urip:     []int{7, 6, 5, 8, 9, 3},
+			position: func(d cycleDay) int { return d.pawukonDay % 6 },
+		},
+		{
+			name:     "saptawara",
+			alias:    "dina",
+			names:    calendar.DayNames,
+			urip:     calendar.DayNeptu,
+			position: func(d cycleDay) int { return d.dayIndex },
+		},
+		{
+			name:     "hastawara",
+			alias:    "padewan",
+			names:    []string{"Sri", "Indra", "Guru", "Yama", "Rudra", "Brahma", "Kala", "Uma"},
+			urip:     []int{6, 5, 8, 9, 3, 7, 1, 4},
+			position: func(d cycleDay) int { return kalaTiga(d.pawukonDay) % 8 },
+		},
+		{
+			// Empat hari pertama pawukon selalu Dangu (Dangu pat)
+			name:  "sangawara",
+			alias: "padangon",
+			names: []string{"Dangu", "Jagur", "Gigis", "Kerangan", "Nohan", "Wogan", "Tulus", "Wurung", "Dadi"},
+			urip:  []int{5, 8, 9, 1, 3, 7, 6, 4, 8},
+			position: func(d cycleDay) int {
+				if d.pawukonDay < 4 {
+					return 0
+				}
+				return (d.pawukonDay - 3) % 9
+			},
+		},
+		{
+			name:     "dasawara",
+			names:    []string{"Pandita", "Pati", "Suka", "Duka", "Sri", "Manuh", "Manusa", "Raja", "Dewa", "Raksasa"},
+			urip:     []int{5, 7, 10, 4, 6, 2, 3, 8, 9, 1},
+			position: func(d cycleDay) int { return d.neptu % 10 },
+		},
+	}
+}
+
+func (s *JavaneseCalendarService) wewaranFor(day cycleDay) []*model.Wewaran {
+	var wewaran []*model.Wewaran
+	for _, cycle := range s.cycles {
+		position := cycle.position(day)
+		wewaran = append(wewaran, &model.Wewaran{
+			Cycle:    cycle.name,
+			Alias:    cycle.alias,
+			Name:     cycle.names[position],
+			Position: position + 1,
+			Urip:     cycle.urip[position],
+		})
+	}
+	return wewaran
+}
+
+// findDayCycle mencari siklus berdasarkan nama (dwiwara ... dasawara) atau
+// sebutan Jawanya (pasaran, paringkelan, padewan, padangon)
+func (s *JavaneseCalendarService) findDayCycle(name string) (dayCycle, bool) {
+	for _, cycle := range s.cycles {
+		if strings.EqualFold(cycle.name, name) || (cycle.alias != "" && strings.EqualFold(cycle.alias, name)) {
+			return cycle, true
+		}
+	}
+	return dayCycle{}, false
+}
+
+// NormalizeWewaran mengembalikan nama siklus dan nama anggota yang baku,
+// misalnya ("paringkelan", "tungle") menjadi ("sadwara", "Tungle")
+func (s *JavaneseCalendarService) NormalizeWewaran(cycleName, value string) (string, string, bool) {
+	cycle, ok := s.findDayCycle(cycleName)
+	if !ok {
+		return "", "", false
+	}
+	for _, name := range cycle.names {
+		if strings.EqualFold(name, value) {
+			return cycle.name, name, true
+		}
+	}
+	return "", "", false
+}
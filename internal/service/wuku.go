@@ -0,0 +1,59 @@
+package service
+
+import "strings"
+
+type wukuData struct {
+	name  string
+	neptu int
+	deity string
+	tree  string
+	bird  string
+}
+
+// Siklus pawukon 30 wuku @ 7 hari (210 hari), dimulai setiap hari Minggu
+var wukuList = []wukuData{
+	{"Sinta", 7, "Bathara Yamadipati", "Kendayakan", "Gagak"},
+	{"Landep", 1, "Bathara Mahadewa", "Kandhayakan", "Atat Kembang"},
+	{"Wukir", 4, "Bathara Mahayekti", "Nagasari", "Manyar"},
+	{"Kurantil", 6, "Bathara Langsur", "Ingas", "Sikatan"},
+	{"Tolu", 5, "Bathara Bayu", "Walikukun", "Branjangan"},
+	{"Gumbreg", 8, "Bathara Candra", "Beringin", "Ayam Alas"},
+	{"Warigalit", 9, "Bathara Asmara", "Sempol", "Kepodang"},
+	{"Warigagung", 3, "Bathara Maharesi", "Cemara", "Bango"},
+	{"Julungwangi", 7, "Bathara Sambu", "Cempaka", "Kutilang"},
+	{"Sungsang", 1, "Bathara Gana", "Tanjung", "Nuri"},
+	{"Galungan", 4, "Bathara Kamajaya", "Tangan", "Bido"},
+	{"Kuningan", 6, "Bathara Indra", "Wilada", "Urang-urangan"},
+	{"Langkir", 5, "Bathara Kala", "Ingas", "Gemak"},
+	{"Mandasiya", 8, "Bathara Brahma", "Asam", "Puter"},
+	{"Julungpujut", 9, "Bathara Guritna", "Rampelas", "Emprit"},
+	{"Pahang", 3, "Bathara Tantra", "Randu", "Kuntul"},
+	{"Kuruwelut", 7, "Bathara Wisnu", "Parijata", "Ayam Alas"},
+	{"Marakeh", 1, "Bathara Surenggana", "Trengguli", "Merak"},
+	{"Tambir", 4, "Bathara Siwah", "Upas", "Prenjak"},
+	{"Medangkungan", 6, "Bathara Basuki", "Nagasari", "Pipit"},
+	{"Maktal", 5, "Bathara Sakri", "Nagasari", "Ayam Alas"},
+	{"Wuye", 8, "Bathara Kuwera", "Tal", "Gagak"},
+	{"Manahil", 9, "Bathara Citragotra", "Tanjung", "Sepepet"},
+	{"Prangbakat", 3, "Bathara Bisma", "Tirisan", "Urang-urangan"},
+	{"Bala", 7, "Bathara Durga", "Cemara", "Ayam Alas"},
+	{"Wugu", 1, "Bathara Singajanma", "Wuni", "Pelatuk"},
+	{"Wayang", 4, "Bathara Sri", "Cempaka", "Ayam Alas"},
+	{"Kulawu", 6, "Bathara Sadana", "Tal", "Podang"},
+	{"Dukut", 5, "Bathara Sakri", "Walikukun", "Ayam Alas"},
+	{"Watugunung", 8, "Bathara Anantaboga", "Wijayakusuma", "Gogik"},
+}
+
+// NormalizeWuku mencocokkan nama wuku tanpa memperhatikan huruf besar,
+// spasi maupun strip, misalnya "julung-wangi" menjadi "Julungwangi"
+func NormalizeWuku(name string) (string, bool) {
+	key := strings.ToLower(name)
+	key = strings.ReplaceAll(key, "-", "")
+	key = strings.ReplaceAll(key, " ", "")
+	for _, wuku := range wukuList {
+		if strings.ToLower(wuku.name) == key {
+			return wuku.name, true
+		}
+	}
+	return "", false
+}
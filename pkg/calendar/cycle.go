@@ -0,0 +1,77 @@
+package calendar
+
+// Names and urip (neptu) of the 7-day week, starting from Sunday
+var (
+	DayNames = []string{"Minggu", "Senin", "Selasa", "Rabu", "Kamis", "Jumat", "Sabtu"}
+	DayNeptu = []int{5, 4, 3, 7, 8, 6, 9}
+)
+
+// Names and urip (neptu) of the 5-day pasaran cycle
+var (
+	PasaranNames = []string{"Legi", "Pahing", "Pon", "Wage", "Kliwon"}
+	PasaranNeptu = []int{5, 9, 7, 4, 8}
+)
+
+// WetonCycle is the length of the combined weekday and pasaran cycle
+const WetonCycle = 35
+
+// PawukonCycle is the length of the pawukon: 30 wuku of 7 days each
+const PawukonCycle = 210
+
+// Sunday 9 February 2025 is the first day of wuku Sinta
+var pawukonEpoch = FromGregorian(2025, 2, 9)
+
+// Weekday returns 0 for Minggu (Sunday) through 6 for Sabtu (Saturday)
+func (j JDN) Weekday() int {
+	return mod(int(j)+1, 7)
+}
+
+// Pasaran returns 0 for Legi through 4 for Kliwon
+func (j JDN) Pasaran() int {
+	return mod(int(j), 5)
+}
+
+// Neptu returns the weton neptu: urip of the weekday plus urip of the pasaran
+func (j JDN) Neptu() int {
+	return DayNeptu[j.Weekday()] + PasaranNeptu[j.Pasaran()]
+}
+
+// PawukonDay returns the position in the 210-day pawukon, 0 being Minggu of
+// wuku Sinta and 209 Sabtu of wuku Watugunung
+func (j JDN) PawukonDay() int {
+	return mod(int(j-pawukonEpoch), PawukonCycle)
+}
+
+// Wuku returns the wuku index, 0 (Sinta) through 29 (Watugunung)
+func (j JDN) Wuku() int {
+	return j.PawukonDay() / 7
+}
+
+// WetonPosition returns the position (0-34) within the 35-day weton cycle
+// of the weton formed by weekday and pasaran. It is the value of
+// JDN.WetonPosition for every day carrying that weton.
+func WetonPosition(weekday, pasaran int) int {
+	// Solve p ≡ pasaran (mod 5) and p+1 ≡ weekday (mod 7); 3 is the inverse of 5 mod 7
+	return pasaran + 5*mod(3*(weekday-1-pasaran), 7)
+}
+
+// WetonPosition returns the position of j within the 35-day weton cycle
+func (j JDN) WetonPosition() int {
+	return mod(int(j), WetonCycle)
+}
+
+// NextWeton returns the first day on or after from that falls on the weton
+// formed by weekday and pasaran, without scanning the days in between
+func NextWeton(from JDN, weekday, pasaran int) JDN {
+	return from + JDN(mod(WetonPosition(weekday, pasaran)-from.WetonPosition(), WetonCycle))
+}
+
+// WetonDays returns every day between first and last (inclusive) that falls
+// on the weton formed by weekday and pasaran
+func WetonDays(first, last JDN, weekday, pasaran int) []JDN {
+	var days []JDN
+	for day := NextWeton(first, weekday, pasaran); day <= last; day += WetonCycle {
+		days = append(days, day)
+	}
+	return days
+}
@@ -0,0 +1,133 @@
+package calendar
+
+import "math"
+
+// HijriEpoch is 1 Muharram 1 AH, Friday 16 July 622 (Julian), the civil epoch
+// of the tabular Islamic calendar
+const HijriEpoch JDN = 1948440
+
+// Hijri month names as written in Indonesia
+var HijriMonthNames = []string{
+	"Muharram", "Safar", "Rabiul Awal", "Rabiul Akhir", "Jumadil Awal", "Jumadil Akhir",
+	"Rajab", "Syakban", "Ramadhan", "Syawal", "Zulkaidah", "Zulhijah",
+}
+
+// HijriDate is an Islamic (Hijri) date
+type HijriDate struct {
+	Year  int
+	Month int // 1 = Muharram ... 12 = Zulhijah
+	Day   int
+}
+
+// FromHijri returns the day number of a tabular Hijri date. Odd months have
+// 30 days, even months 29, and Zulhijah gains a day in the 11 leap years
+// (2, 5, 7, 10, 13, 16, 18, 21, 24, 26, 29) of each 30-year cycle.
+func FromHijri(date HijriDate) JDN {
+	return HijriEpoch - 1 + JDN(date.Day+
+		int(math.Ceil(29.5*float64(date.Month-1)))+
+		(date.Year-1)*354+
+		floorDiv(3+11*date.Year, 30))
+}
+
+// ToHijri converts a day number to the tabular Hijri calendar. adjustment
+// shifts the result by whole days, as published calendars based on sighting
+// (rukyat) often differ from the arithmetic by one or two days.
+func ToHijri(j JDN, adjustment int) HijriDate {
+	j += JDN(adjustment)
+	year := floorDiv(30*int(j-HijriEpoch)+10646, 10631)
+	month := int(math.Ceil(float64(j-FromHijri(HijriDate{year, 1, 1})-29)/29.5)) + 1
+	if month > 12 {
+		month = 12
+	}
+	if month < 1 {
+		month = 1
+	}
+	day := int(j-FromHijri(HijriDate{year, month, 1})) + 1
+	return HijriDate{Year: year, Month: month, Day: day}
+}
+
+// meanSynodicMonth is the average time between two new moons in days
+const meanSynodicMonth = 29.530588861
+
+// NewMoon returns the moment (Julian Ephemeris Day) of the k-th new moon
+// counted from the one of 6 January 2000, following Meeus, Astronomical
+// Algorithms ch. 49 without the planetary terms. The error is within a
+// couple of minutes for recent centuries.
+func NewMoon(k int) float64 {
+	kf := float64(k)
+	t := kf / 1236.85
+	t2, t3, t4 := t*t, t*t*t, t*t*t*t
+
+	jde := 2451550.09766 + meanSynodicMonth*kf + 0.00015437*t2 - 0.000000150*t3 + 0.00000000073*t4
+	e := 1 - 0.002516*t - 0.0000074*t2
+
+	rad := math.Pi / 180
+	m := (2.5534 + 29.10535670*kf - 0.0000014*t2 - 0.00000011*t3) * rad
+	mp := (201.5643 + 385.81693528*kf + 0.0107582*t2 + 0.00001238*t3 - 0.000000058*t4) * rad
+	f := (160.7108 + 390.67050284*kf - 0.0016118*t2 - 0.00000227*t3 + 0.000000011*t4) * rad
+	omega := (124.7746 - 1.56375588*kf + 0.0020672*t2 + 0.00000215*t3) * rad
+
+	jde += -0.40720*math.Sin(mp) +
+		0.17241*e*math.Sin(m) +
+		0.01608*math.Sin(2*mp) +
+		0.01039*math.Sin(2*f) +
+		0.00739*e*math.Sin(mp-m) -
+		0.00514*e*math.Sin(mp+m) +
+		0.00208*e*e*math.Sin(2*m) -
+		0.00111*math.Sin(mp-2*f) -
+		0.00057*math.Sin(mp+2*f) +
+		0.00056*e*math.Sin(2*mp+m) -
+		0.00042*math.Sin(3*mp) +
+		0.00042*e*math.Sin(m+2*f) +
+		0.00038*e*math.Sin(m-2*f) -
+		0.00024*e*math.Sin(2*mp-m) -
+		0.00017*math.Sin(omega) -
+		0.00007*math.Sin(mp+2*m) +
+		0.00004*math.Sin(2*mp-2*f) +
+		0.00004*math.Sin(3*m) +
+		0.00003*math.Sin(mp+m-2*f) +
+		0.00003*math.Sin(2*mp+2*f) -
+		0.00003*math.Sin(mp+m+2*f) +
+		0.00003*math.Sin(mp-m+2*f) -
+		0.00002*math.Sin(mp-m-2*f) -
+		0.00002*math.Sin(3*mp+m) +
+		0.00002*math.Sin(4*mp)
+
+	return jde
+}
+
+// HisabObserver describes where the hisab criterion is evaluated
+type HisabObserver struct {
+	UTCOffset float64 // hours
+	// Sunset returns local sunset on a day in hours after midnight
+	Sunset func(day JDN) float64
+}
+
+// hisabMonthStart applies the ijtimak qablal ghurub criterion: a month starts
+// the day after the evening on which the conjunction has already happened
+func hisabMonthStart(k int, observer HisabObserver) JDN {
+	local := NewMoon(k) + observer.UTCOffset/24 + 0.5
+	day := JDN(math.Floor(local))
+	hour := (local - math.Floor(local)) * 24
+	if hour < observer.Sunset(day) {
+		return day + 1
+	}
+	return day + 2
+}
+
+// ToHijriHisab converts a day number to a Hijri date whose months start by
+// astronomical reckoning (hisab) rather than by the tabular rule
+func ToHijriHisab(j JDN, observer HisabObserver) HijriDate {
+	k := int(math.Floor(float64(j-2451550) / meanSynodicMonth))
+	for hisabMonthStart(k, observer) > j {
+		k--
+	}
+	for hisabMonthStart(k+1, observer) <= j {
+		k++
+	}
+
+	start := hisabMonthStart(k, observer)
+	// The tabular date in the middle of the month names the month
+	label := ToHijri(start+14, 0)
+	return HijriDate{Year: label.Year, Month: label.Month, Day: int(j-start) + 1}
+}
@@ -0,0 +1,52 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHijri(t *testing.T) {
+	tests := []struct {
+		date JDN
+		want HijriDate
+	}{
+		{FromJulian(622, time.July, 16), HijriDate{1, 1, 1}},
+		{FromGregorian(2024, time.April, 10), HijriDate{1445, 10, 1}},
+		{FromGregorian(2025, time.March, 1), HijriDate{1446, 9, 1}},
+		{FromGregorian(2025, time.June, 27), HijriDate{1447, 1, 1}},
+	}
+
+	for _, tt := range tests {
+		if got := ToHijri(tt.date, 0); got != tt.want {
+			t.Errorf("ToHijri(%s) = %+v, want %+v", tt.date, got, tt.want)
+		}
+		if got := FromHijri(tt.want); got != tt.date {
+			t.Errorf("FromHijri(%+v) = %s, want %s", tt.want, got, tt.date)
+		}
+	}
+
+	if HijriEpoch != FromJulian(622, time.July, 16) {
+		t.Errorf("HijriEpoch = %d, want Julian 622-07-16", HijriEpoch)
+	}
+}
+
+func TestHijriRoundTrip(t *testing.T) {
+	for j := HijriEpoch; j <= FromGregorian(9999, time.December, 31); j++ {
+		date := ToHijri(j, 0)
+		if date.Month < 1 || date.Month > 12 || date.Day < 1 || date.Day > 30 {
+			t.Fatalf("ToHijri(%s) = %+v is not a valid date", j, date)
+		}
+		if back := FromHijri(date); back != j {
+			t.Fatalf("%s -> %+v -> %s", j, date, back)
+		}
+	}
+}
+
+func TestHijriAdjustment(t *testing.T) {
+	j := FromGregorian(2025, time.March, 1)
+	for adjustment := -3; adjustment <= 3; adjustment++ {
+		if got, want := ToHijri(j, adjustment), ToHijri(j+JDN(adjustment), 0); got != want {
+			t.Errorf("ToHijri(%s, %d) = %+v, want %+v", j, adjustment, got, want)
+		}
+	}
+}
@@ -0,0 +1,210 @@
+package calendar
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Kurup selects how Javanese dates are reckoned. KurupHistoris follows the
+// historical succession of kurup eras; any other kurup applies its own
+// windu arithmetic to every year without the historical one-day corrections.
+type Kurup string
+
+const (
+	KurupHistoris Kurup = ""
+	KurupAjumgi   Kurup = "ajumgi"
+	KurupAmiswon  Kurup = "amiswon"
+	KurupAboge    Kurup = "aboge"
+	KurupAsapon   Kurup = "asapon"
+	KurupAnenge   Kurup = "anenge"
+)
+
+type kurupEra struct {
+	kurup     Kurup
+	name      string
+	startYear int
+}
+
+// Amiswon only lasted 72 years; every other kurup spans 120 years (15 windu).
+// The historical reckoning drops one day in the last year before each new era.
+var kurupEras = []kurupEra{
+	{KurupAjumgi, "Ajumgi", 1555},
+	{KurupAmiswon, "Amiswon", 1675},
+	{KurupAboge, "Aboge", 1747},
+	{KurupAsapon, "Asapon", 1867},
+	{KurupAnenge, "Anenge", 1987},
+}
+
+// ParseKurup accepts a kurup name such as "aboge"; "" and "historis" select
+// the historical reckoning
+func ParseKurup(name string) (Kurup, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" || name == "historis" {
+		return KurupHistoris, nil
+	}
+	for _, era := range kurupEras {
+		if string(era.kurup) == name {
+			return era.kurup, nil
+		}
+	}
+	return KurupHistoris, fmt.Errorf("unknown kurup %q", name)
+}
+
+func (k Kurup) era() (kurupEra, bool) {
+	for _, era := range kurupEras {
+		if era.kurup == k {
+			return era, true
+		}
+	}
+	return kurupEra{}, false
+}
+
+// NameFor returns the name of the kurup in force for a Javanese year
+func (k Kurup) NameFor(year int) string {
+	if era, ok := k.era(); ok {
+		return era.name
+	}
+	name := ""
+	for _, era := range kurupEras {
+		if year >= era.startYear {
+			name = era.name
+		}
+	}
+	return name
+}
+
+// Javanese month names, Sura through Besar
+var MonthNames = []string{
+	"Sura", "Sapar", "Mulud", "Bakda Mulud", "Jumadil Awal", "Jumadil Akhir",
+	"Rejeb", "Ruwah", "Pasa", "Sawal", "Sela", "Besar",
+}
+
+// Year names and their urip within a windu, Alip through Jimakir
+var (
+	YearNames = []string{"Alip", "Ehe", "Jimawal", "Je", "Dal", "Be", "Wawu", "Jimakir"}
+	YearNeptu = []int{1, 5, 3, 7, 4, 2, 6, 3}
+)
+
+// WinduNames cycle every four windu (32 years)
+var WinduNames = []string{"Adi", "Kuntara", "Sengara", "Sancaya"}
+
+// 1 Sura 1555 Alip, the first day of the Sultan Agung calendar, fell on
+// Jumat Legi 8 July 1633
+const EpochYear = 1555
+
+var Epoch = FromGregorian(1633, 7, 8)
+
+// A windu has 5 years of 354 days and 3 kabisat years (Ehe, Dal, Jimakir)
+// of 355 days
+const winduDays = 2835
+
+// winduOffsets[i] is the number of days from 1 Sura Alip to 1 Sura of the
+// i-th year of the windu
+var winduOffsets = [9]int{0, 354, 709, 1063, 1417, 1772, 2126, 2480, 2835}
+
+// Date is a Javanese (Anno Javanico) date
+type Date struct {
+	Year  int
+	Month int // 1 = Sura ... 12 = Besar
+	Day   int
+}
+
+// WinduYear returns the position of year in its windu, 0 (Alip) to 7 (Jimakir)
+func WinduYear(year int) int {
+	return mod(year-EpochYear, 8)
+}
+
+// IsKabisat reports whether year is a 355-day leap year in windu arithmetic
+func IsKabisat(year int) bool {
+	switch WinduYear(year) {
+	case 1, 4, 7:
+		return true
+	}
+	return false
+}
+
+// Windu returns the windu name index for year; 1955 Alip opened Windu Adi
+func Windu(year int) int {
+	return mod(floorDiv(year-EpochYear, 8)+2, 4)
+}
+
+// winduDaysBefore counts the days from 1 Sura 1555 to 1 Sura of year using
+// plain windu arithmetic
+func winduDaysBefore(year int) int {
+	n := year - EpochYear
+	return floorDiv(n, 8)*winduDays + winduOffsets[mod(n, 8)]
+}
+
+// historicalYearStart applies the one-day drop before each kurup era
+func historicalYearStart(year int) JDN {
+	drops := 0
+	for _, era := range kurupEras[1:] {
+		if era.startYear <= year {
+			drops++
+		}
+	}
+	return Epoch + JDN(winduDaysBefore(year)-drops)
+}
+
+// YearStart returns the day of 1 Sura of year
+func YearStart(year int, kurup Kurup) JDN {
+	era, ok := kurup.era()
+	if !ok {
+		return historicalYearStart(year)
+	}
+	return historicalYearStart(era.startYear) + JDN(winduDaysBefore(year)-winduDaysBefore(era.startYear))
+}
+
+// YearLength returns the number of days in year (353 to 355)
+func YearLength(year int, kurup Kurup) int {
+	return YearStart(year+1, kurup).Sub(YearStart(year, kurup))
+}
+
+// MonthLength returns 30 for odd months and 29 for even months, except Besar
+// which absorbs the kabisat day and any kurup correction
+func MonthLength(year, month int, kurup Kurup) int {
+	if month == 12 {
+		return YearLength(year, kurup) - 325
+	}
+	if month%2 == 1 {
+		return 30
+	}
+	return 29
+}
+
+// ToJavanese converts a day number to a Javanese date
+func ToJavanese(j JDN, kurup Kurup) Date {
+	year := EpochYear + floorDiv(j.Sub(Epoch)*8, winduDays)
+	for YearStart(year, kurup) > j {
+		year--
+	}
+	for YearStart(year+1, kurup) <= j {
+		year++
+	}
+
+	days := j.Sub(YearStart(year, kurup))
+	month := 1
+	for days >= MonthLength(year, month, kurup) {
+		days -= MonthLength(year, month, kurup)
+		month++
+	}
+
+	return Date{Year: year, Month: month, Day: days + 1}
+}
+
+// FromJavanese converts a Javanese date to a day number
+func FromJavanese(date Date, kurup Kurup) (JDN, error) {
+	if date.Month < 1 || date.Month > 12 {
+		return 0, fmt.Errorf("month %d out of range 1-12", date.Month)
+	}
+	length := MonthLength(date.Year, date.Month, kurup)
+	if date.Day < 1 || date.Day > length {
+		return 0, fmt.Errorf("%s %d has %d days", MonthNames[date.Month-1], date.Year, length)
+	}
+
+	j := YearStart(date.Year, kurup)
+	for month := 1; month < date.Month; month++ {
+		j += JDN(MonthLength(date.Year, month, kurup))
+	}
+	return j + JDN(date.Day-1), nil
+}
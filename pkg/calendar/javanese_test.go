@@ -0,0 +1,145 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+)
+
+var allKurups = []Kurup{KurupHistoris, KurupAjumgi, KurupAmiswon, KurupAboge, KurupAsapon, KurupAnenge}
+
+func TestEpoch(t *testing.T) {
+	if Epoch != 2317690 {
+		t.Errorf("Epoch = %d, want 2317690 (1633-07-08)", Epoch)
+	}
+	if got := ToJavanese(Epoch, KurupHistoris); got != (Date{EpochYear, 1, 1}) {
+		t.Errorf("ToJavanese(Epoch) = %+v, want 1 Sura %d", got, EpochYear)
+	}
+}
+
+func TestToJavanese(t *testing.T) {
+	tests := []struct {
+		date JDN
+		want Date
+	}{
+		{FromGregorian(1633, time.July, 8), Date{1555, 1, 1}},
+		{FromGregorian(1936, time.March, 24), Date{1867, 1, 1}},
+		{FromGregorian(2025, time.June, 26), Date{1958, 12, 29}},
+		{FromGregorian(2025, time.June, 27), Date{1959, 1, 1}},
+		{FromGregorian(2025, time.August, 25), Date{1959, 3, 1}},
+	}
+
+	for _, tt := range tests {
+		if got := ToJavanese(tt.date, KurupHistoris); got != tt.want {
+			t.Errorf("ToJavanese(%s) = %+v, want %+v", tt.date, got, tt.want)
+		}
+	}
+}
+
+// Each kurup is named after the weton of 1 Sura Alip that opens it, and the
+// historical reckoning drops a day from the Jimakir year before each new era
+func TestKurupEras(t *testing.T) {
+	tests := []struct {
+		kurup     Kurup
+		name      string
+		startYear int
+		start     string
+		day       string
+		pasaran   string
+	}{
+		{KurupAjumgi, "Ajumgi", 1555, "1633-07-08", "Jumat", "Legi"},
+		{KurupAmiswon, "Amiswon", 1675, "1749-12-11", "Kamis", "Kliwon"},
+		{KurupAboge, "Aboge", 1747, "1819-10-20", "Rabu", "Wage"},
+		{KurupAsapon, "Asapon", 1867, "1936-03-24", "Selasa", "Pon"},
+		{KurupAnenge, "Anenge", 1987, "2052-08-26", "Senin", "Pahing"},
+	}
+
+	for i, tt := range tests {
+		start := YearStart(tt.startYear, KurupHistoris)
+		if start.String() != tt.start {
+			t.Errorf("1 Sura %d = %s, want %s", tt.startYear, start, tt.start)
+		}
+		if got := YearStart(tt.startYear, tt.kurup); got != start {
+			t.Errorf("1 Sura %d in %s = %s, want %s", tt.startYear, tt.name, got, start)
+		}
+		if DayNames[start.Weekday()] != tt.day || PasaranNames[start.Pasaran()] != tt.pasaran {
+			t.Errorf("1 Sura %d falls on %s %s, want %s %s", tt.startYear, DayNames[start.Weekday()], PasaranNames[start.Pasaran()], tt.day, tt.pasaran)
+		}
+		if WinduYear(tt.startYear) != 0 {
+			t.Errorf("%s starts in %s, want Alip", tt.name, YearNames[WinduYear(tt.startYear)])
+		}
+		if got := KurupHistoris.NameFor(tt.startYear); got != tt.name {
+			t.Errorf("NameFor(%d) = %s, want %s", tt.startYear, got, tt.name)
+		}
+
+		if i == 0 {
+			continue
+		}
+		previous := tests[i-1].name
+		if got := KurupHistoris.NameFor(tt.startYear - 1); got != previous {
+			t.Errorf("NameFor(%d) = %s, want %s", tt.startYear-1, got, previous)
+		}
+		if got := YearLength(tt.startYear-1, KurupHistoris); got != 354 {
+			t.Errorf("historical year %d has %d days, want 354 after the kurup correction", tt.startYear-1, got)
+		}
+		if got := YearLength(tt.startYear-1, tt.kurup); got != 355 {
+			t.Errorf("year %d in %s has %d days, want 355", tt.startYear-1, tt.name, got)
+		}
+	}
+}
+
+func TestWinduLength(t *testing.T) {
+	for _, kurup := range allKurups[1:] {
+		days := 0
+		for year := 1955; year < 1963; year++ {
+			days += YearLength(year, kurup)
+		}
+		if days != winduDays {
+			t.Errorf("windu 1955-1962 in %s has %d days, want %d", kurup, days, winduDays)
+		}
+	}
+	if Windu(1955) != 0 || YearNames[WinduYear(1955)] != "Alip" {
+		t.Errorf("1955 = %s, windu %s; want Alip, windu Adi", YearNames[WinduYear(1955)], WinduNames[Windu(1955)])
+	}
+}
+
+func TestJavaneseRoundTrip(t *testing.T) {
+	first, last := Epoch, FromGregorian(2200, time.December, 31)
+	for _, kurup := range allKurups {
+		previous := ToJavanese(first, kurup)
+		for j := first; j <= last; j++ {
+			date := ToJavanese(j, kurup)
+			back, err := FromJavanese(date, kurup)
+			if err != nil || back != j {
+				t.Fatalf("kurup %q: %s -> %+v -> %s (%v)", kurup, j, date, back, err)
+			}
+
+			if j == first {
+				continue
+			}
+			// Consecutive days must advance the Javanese date by exactly one day
+			switch {
+			case date.Year == previous.Year && date.Month == previous.Month && date.Day == previous.Day+1:
+			case date.Year == previous.Year && date.Month == previous.Month+1 && date.Day == 1:
+			case date.Year == previous.Year+1 && date.Month == 1 && date.Day == 1 && previous.Month == 12:
+			default:
+				t.Fatalf("kurup %q: %+v followed by %+v on %s", kurup, previous, date, j)
+			}
+			previous = date
+		}
+	}
+}
+
+func TestFromJavaneseInvalid(t *testing.T) {
+	tests := []Date{
+		{1959, 0, 1},
+		{1959, 13, 1},
+		{1959, 2, 30},
+		{1959, 1, 0},
+	}
+
+	for _, date := range tests {
+		if _, err := FromJavanese(date, KurupHistoris); err == nil {
+			t.Errorf("FromJavanese(%+v) accepted an invalid date", date)
+		}
+	}
+}
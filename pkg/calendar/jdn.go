@@ -0,0 +1,100 @@
+// Package calendar is the pure calendar core behind the Jakal API. Every
+// date is a Julian Day Number (an integer day count), so conversion, cycle
+// positions and day arithmetic need no time zones or floating point and stay
+// exact far away from 1970.
+package calendar
+
+import (
+	"fmt"
+	"time"
+)
+
+// JDN is a Julian Day Number: the number of days since 1 January 4713 BC
+// (proleptic Julian calendar). Adding or subtracting integers moves by days.
+type JDN int
+
+const (
+	// UnixEpoch is 1970-01-01
+	UnixEpoch JDN = 2440588
+	// rataDieOffset makes Rata Die 1 fall on 0001-01-01 (proleptic Gregorian)
+	rataDieOffset = 1721425
+)
+
+// FromGregorian returns the day number of a proleptic Gregorian date
+func FromGregorian(year int, month time.Month, day int) JDN {
+	a := floorDiv(14-int(month), 12)
+	y := year + 4800 - a
+	m := int(month) + 12*a - 3
+	return JDN(day + (153*m+2)/5 + 365*y + floorDiv(y, 4) - floorDiv(y, 100) + floorDiv(y, 400) - 32045)
+}
+
+// FromTime returns the day number of t's calendar date in t's own location
+func FromTime(t time.Time) JDN {
+	year, month, day := t.Date()
+	return FromGregorian(year, month, day)
+}
+
+// FromRataDie converts a Rata Die day count to a day number
+func FromRataDie(rd int) JDN {
+	return JDN(rd + rataDieOffset)
+}
+
+// Gregorian returns the proleptic Gregorian date of j
+func (j JDN) Gregorian() (year int, month time.Month, day int) {
+	a := int(j) + 32044
+	b := floorDiv(4*a+3, 146097)
+	c := a - floorDiv(146097*b, 4)
+	d := floorDiv(4*c+3, 1461)
+	e := c - floorDiv(1461*d, 4)
+	m := floorDiv(5*e+2, 153)
+
+	day = e - floorDiv(153*m+2, 5) + 1
+	month = time.Month(m + 3 - 12*floorDiv(m, 10))
+	year = 100*b + d - 4800 + floorDiv(m, 10)
+	return year, month, day
+}
+
+// Time returns midnight UTC of j
+func (j JDN) Time() time.Time {
+	year, month, day := j.Gregorian()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// RataDie returns the Rata Die day count of j
+func (j JDN) RataDie() int {
+	return int(j) - rataDieOffset
+}
+
+// AddDays returns the day n days after j (n may be negative)
+func (j JDN) AddDays(n int) JDN {
+	return j + JDN(n)
+}
+
+// Sub returns the number of days from other to j
+func (j JDN) Sub(other JDN) int {
+	return int(j - other)
+}
+
+// String formats j as YYYY-MM-DD
+func (j JDN) String() string {
+	year, month, day := j.Gregorian()
+	return fmt.Sprintf("%04d-%02d-%02d", year, int(month), day)
+}
+
+// floorDiv and mod round towards negative infinity so cycle positions stay
+// correct for days before their epoch
+func floorDiv(a, b int) int {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
+
+func mod(a, b int) int {
+	m := a % b
+	if m < 0 {
+		m += b
+	}
+	return m
+}
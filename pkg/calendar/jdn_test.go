@@ -0,0 +1,91 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFromGregorian(t *testing.T) {
+	tests := []struct {
+		year  int
+		month time.Month
+		day   int
+		want  JDN
+	}{
+		{1, time.January, 1, 1721426},
+		{1582, time.October, 15, 2299161},
+		{1633, time.July, 8, 2317690},
+		{1970, time.January, 1, UnixEpoch},
+		{2000, time.January, 1, 2451545},
+		{9999, time.December, 31, 5373484},
+	}
+
+	for _, tt := range tests {
+		if got := FromGregorian(tt.year, tt.month, tt.day); got != tt.want {
+			t.Errorf("FromGregorian(%d, %d, %d) = %d, want %d", tt.year, tt.month, tt.day, got, tt.want)
+		}
+	}
+}
+
+func TestGregorianRoundTrip(t *testing.T) {
+	first, last := FromGregorian(1, time.January, 1), FromGregorian(9999, time.December, 31)
+	for j := first; j <= last; j++ {
+		year, month, day := j.Gregorian()
+		if back := FromGregorian(year, month, day); back != j {
+			t.Fatalf("JDN %d -> %04d-%02d-%02d -> %d", j, year, month, day, back)
+		}
+	}
+}
+
+func TestTimeAndString(t *testing.T) {
+	j := FromGregorian(1633, time.July, 8)
+	if got := j.String(); got != "1633-07-08" {
+		t.Errorf("String() = %q, want 1633-07-08", got)
+	}
+	if got := FromTime(j.Time()); got != j {
+		t.Errorf("FromTime(Time()) = %d, want %d", got, j)
+	}
+	if got := FromRataDie(j.RataDie()); got != j {
+		t.Errorf("FromRataDie(RataDie()) = %d, want %d", got, j)
+	}
+}
+
+func TestWeton(t *testing.T) {
+	tests := []struct {
+		date    JDN
+		day     string
+		pasaran string
+		neptu   int
+	}{
+		{FromGregorian(1, time.January, 1), "Senin", "Pahing", 13},
+		{Epoch, "Jumat", "Legi", 11},
+		{FromGregorian(1936, time.March, 24), "Selasa", "Pon", 10},
+		{FromGregorian(2000, time.January, 1), "Sabtu", "Legi", 14},
+		{FromGregorian(2025, time.June, 27), "Jumat", "Kliwon", 14},
+	}
+
+	for _, tt := range tests {
+		day, pasaran := DayNames[tt.date.Weekday()], PasaranNames[tt.date.Pasaran()]
+		if day != tt.day || pasaran != tt.pasaran || tt.date.Neptu() != tt.neptu {
+			t.Errorf("%s = %s %s (neptu %d), want %s %s (neptu %d)", tt.date, day, pasaran, tt.date.Neptu(), tt.day, tt.pasaran, tt.neptu)
+		}
+	}
+}
+
+func TestNextWeton(t *testing.T) {
+	from := FromGregorian(2025, time.January, 1)
+	for weekday := 0; weekday < 7; weekday++ {
+		for pasaran := 0; pasaran < 5; pasaran++ {
+			next := NextWeton(from, weekday, pasaran)
+			if next < from || next.Sub(from) >= WetonCycle {
+				t.Fatalf("NextWeton(%s, %d, %d) = %s, outside one weton cycle", from, weekday, pasaran, next)
+			}
+			if next.Weekday() != weekday || next.Pasaran() != pasaran {
+				t.Errorf("NextWeton(%s, %d, %d) = %s with weton %d/%d", from, weekday, pasaran, next, next.Weekday(), next.Pasaran())
+			}
+			if WetonPosition(weekday, pasaran) != next.WetonPosition() {
+				t.Errorf("WetonPosition(%d, %d) = %d, day position %d", weekday, pasaran, WetonPosition(weekday, pasaran), next.WetonPosition())
+			}
+		}
+	}
+}
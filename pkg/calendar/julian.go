@@ -0,0 +1,126 @@
+package calendar
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// GregorianReform is 15 October 1582, the first day of the Gregorian
+// calendar; it directly followed Julian 4 October 1582
+var GregorianReform = FromGregorian(1582, 10, 15)
+
+// System selects how year-month-day triples are read and written
+type System string
+
+const (
+	// SystemHistorical uses the Julian calendar before GregorianReform and
+	// the Gregorian calendar from then on
+	SystemHistorical System = ""
+	// SystemGregorian uses the proleptic Gregorian calendar for every date
+	SystemGregorian System = "gregorian"
+	// SystemJulian uses the proleptic Julian calendar for every date
+	SystemJulian System = "julian"
+)
+
+// ParseSystem accepts "historical", "gregorian" or "julian"; "" selects
+// SystemHistorical
+func ParseSystem(name string) (System, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "historical", "historis":
+		return SystemHistorical, nil
+	case "gregorian", "gregorius", "proleptic":
+		return SystemGregorian, nil
+	case "julian", "julius":
+		return SystemJulian, nil
+	}
+	return SystemHistorical, fmt.Errorf("unknown calendar system %q", name)
+}
+
+// FromJulian returns the day number of a proleptic Julian date
+func FromJulian(year int, month time.Month, day int) JDN {
+	a := floorDiv(14-int(month), 12)
+	y := year + 4800 - a
+	m := int(month) + 12*a - 3
+	return JDN(day + (153*m+2)/5 + 365*y + floorDiv(y, 4) - 32083)
+}
+
+// Julian returns the proleptic Julian date of j
+func (j JDN) Julian() (year int, month time.Month, day int) {
+	c := int(j) + 32082
+	d := floorDiv(4*c+3, 1461)
+	e := c - floorDiv(1461*d, 4)
+	m := floorDiv(5*e+2, 153)
+
+	day = e - floorDiv(153*m+2, 5) + 1
+	month = time.Month(m + 3 - 12*floorDiv(m, 10))
+	year = d - 4800 + floorDiv(m, 10)
+	return year, month, day
+}
+
+// FromDate returns the day number of a date written in system. It fails for
+// dates that do not exist in that system, such as 1582-10-10 historically or
+// 1900-02-29 in the Gregorian calendar.
+func FromDate(system System, year int, month time.Month, day int) (JDN, error) {
+	var j JDN
+	switch system {
+	case SystemGregorian:
+		j = FromGregorian(year, month, day)
+	case SystemJulian:
+		j = FromJulian(year, month, day)
+	default:
+		j = FromJulian(year, month, day)
+		if j >= GregorianReform {
+			j = FromGregorian(year, month, day)
+		}
+	}
+
+	y, m, d := j.Date(system)
+	if y != year || m != month || d != day {
+		return 0, fmt.Errorf("%04d-%02d-%02d does not exist in the %s calendar", year, int(month), day, system.name())
+	}
+	return j, nil
+}
+
+// Date returns the date of j written in system
+func (j JDN) Date(system System) (year int, month time.Month, day int) {
+	switch system {
+	case SystemGregorian:
+		return j.Gregorian()
+	case SystemJulian:
+		return j.Julian()
+	}
+	if j < GregorianReform {
+		return j.Julian()
+	}
+	return j.Gregorian()
+}
+
+// Format writes j as YYYY-MM-DD in system
+func (j JDN) Format(system System) string {
+	year, month, day := j.Date(system)
+	return fmt.Sprintf("%04d-%02d-%02d", year, int(month), day)
+}
+
+func (system System) name() string {
+	if system == SystemHistorical {
+		return "historical"
+	}
+	return string(system)
+}
+
+// SakaYear returns the Saka era year of j, the reckoning used in Java before
+// Sultan Agung's reform. The year is taken to begin on 1 Caitra of the Indian
+// national calendar (22 March, or 21 March in leap years); the lunisolar
+// Javanese Saka year could begin a few weeks off that date.
+func (j JDN) SakaYear() int {
+	year, _, _ := j.Gregorian()
+	newYear := FromGregorian(year, time.March, 22)
+	if year%4 == 0 && (year%100 != 0 || year%400 == 0) {
+		newYear--
+	}
+	if j < newYear {
+		return year - 79
+	}
+	return year - 78
+}
@@ -0,0 +1,83 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGregorianReform(t *testing.T) {
+	lastJulian := FromJulian(1582, time.October, 4)
+	if lastJulian+1 != GregorianReform {
+		t.Fatalf("Julian 1582-10-04 + 1 = %d, want GregorianReform %d", lastJulian+1, GregorianReform)
+	}
+	if got := lastJulian.Format(SystemHistorical); got != "1582-10-04" {
+		t.Errorf("day before the reform = %s, want 1582-10-04", got)
+	}
+	if got := GregorianReform.Format(SystemHistorical); got != "1582-10-15" {
+		t.Errorf("reform day = %s, want 1582-10-15", got)
+	}
+	if got := GregorianReform.Format(SystemJulian); got != "1582-10-05" {
+		t.Errorf("reform day in the Julian calendar = %s, want 1582-10-05", got)
+	}
+}
+
+func TestFromDate(t *testing.T) {
+	tests := []struct {
+		system System
+		date   string
+		year   int
+		month  time.Month
+		day    int
+		valid  bool
+	}{
+		{SystemHistorical, "1500-02-29", 1500, time.February, 29, true},
+		{SystemGregorian, "1500-02-29", 1500, time.February, 29, false},
+		{SystemHistorical, "1582-10-04", 1582, time.October, 4, true},
+		{SystemHistorical, "1582-10-10", 1582, time.October, 10, false},
+		{SystemJulian, "1582-10-10", 1582, time.October, 10, true},
+		{SystemHistorical, "1582-10-15", 1582, time.October, 15, true},
+		{SystemGregorian, "1900-02-29", 1900, time.February, 29, false},
+		{SystemJulian, "1900-02-29", 1900, time.February, 29, true},
+		{SystemHistorical, "2000-02-29", 2000, time.February, 29, true},
+		{SystemHistorical, "2025-02-29", 2025, time.February, 29, false},
+	}
+
+	for _, tt := range tests {
+		j, err := FromDate(tt.system, tt.year, tt.month, tt.day)
+		if (err == nil) != tt.valid {
+			t.Errorf("FromDate(%q, %s) error = %v, valid %v", tt.system, tt.date, err, tt.valid)
+			continue
+		}
+		if tt.valid && j.Format(tt.system) != tt.date {
+			t.Errorf("FromDate(%q, %s) formats back as %s", tt.system, tt.date, j.Format(tt.system))
+		}
+	}
+}
+
+func TestJulianRoundTrip(t *testing.T) {
+	first, last := FromJulian(1, time.January, 1), FromJulian(9999, time.December, 31)
+	for j := first; j <= last; j++ {
+		year, month, day := j.Julian()
+		if back := FromJulian(year, month, day); back != j {
+			t.Fatalf("JDN %d -> Julian %04d-%02d-%02d -> %d", j, year, month, day, back)
+		}
+	}
+}
+
+func TestSakaYear(t *testing.T) {
+	tests := []struct {
+		date JDN
+		want int
+	}{
+		{FromGregorian(1633, time.March, 21), 1554},
+		{FromGregorian(1633, time.March, 22), 1555},
+		{FromGregorian(2024, time.March, 20), 1945},
+		{FromGregorian(2024, time.March, 21), 1946},
+	}
+
+	for _, tt := range tests {
+		if got := tt.date.SakaYear(); got != tt.want {
+			t.Errorf("%s SakaYear() = %d, want %d", tt.date, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+// Package ical writes RFC 5545 iCalendar documents. Only what the Jakal
+// API needs is covered: all-day events, optionally recurring, with text
+// escaping and line folding done according to the RFC.
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/yuxxeun/jakal/pkg/calendar"
+)
+
+// ContentType is the media type of an encoded calendar
+const ContentType = "text/calendar; charset=utf-8"
+
+// maxLineOctets is the longest content line allowed before folding
+const maxLineOctets = 75
+
+// Calendar is a VCALENDAR object
+type Calendar struct {
+	ProdID      string
+	Name        string
+	Description string
+	// RefreshInterval hints subscribing clients how often to poll a feed;
+	// zero leaves the properties out
+	RefreshInterval time.Duration
+	// Stamp is written as DTSTAMP on every event; zero means time.Now
+	Stamp  time.Time
+	Events []Event
+}
+
+// Event is an all-day VEVENT
+type Event struct {
+	UID         string
+	Start       calendar.JDN
+	Summary     string
+	Description string
+	Categories  []string
+	// RRule is a recurrence rule without the "RRULE:" prefix,
+	// for example "FREQ=DAILY;INTERVAL=35"
+	RRule string
+}
+
+// Encode writes the calendar to w with CRLF line endings
+func (c *Calendar) Encode(w io.Writer) error {
+	e := &encoder{w: bufio.NewWriter(w)}
+
+	stamp := c.Stamp
+	if stamp.IsZero() {
+		stamp = time.Now()
+	}
+
+	e.line("BEGIN", "VCALENDAR")
+	e.line("VERSION", "2.0")
+	e.line("PRODID", c.ProdID)
+	e.line("CALSCALE", "GREGORIAN")
+	e.line("METHOD", "PUBLISH")
+	if c.Name != "" {
+		e.line("X-WR-CALNAME", Escape(c.Name))
+	}
+	if c.Description != "" {
+		e.line("X-WR-CALDESC", Escape(c.Description))
+	}
+	if c.RefreshInterval > 0 {
+		e.line("REFRESH-INTERVAL;VALUE=DURATION", duration(c.RefreshInterval))
+		e.line("X-PUBLISHED-TTL", duration(c.RefreshInterval))
+	}
+
+	for _, event := range c.Events {
+		e.line("BEGIN", "VEVENT")
+		e.line("UID", event.UID)
+		e.line("DTSTAMP", stamp.UTC().Format("20060102T150405Z"))
+		e.line("DTSTART;VALUE=DATE", FormatDate(event.Start))
+		e.line("DTEND;VALUE=DATE", FormatDate(event.Start+1))
+		if event.RRule != "" {
+			e.line("RRULE", event.RRule)
+		}
+		e.line("SUMMARY", Escape(event.Summary))
+		if event.Description != "" {
+			e.line("DESCRIPTION", Escape(event.Description))
+		}
+		if len(event.Categories) > 0 {
+			categories := make([]string, len(event.Categories))
+			for i, category := range event.Categories {
+				categories[i] = Escape(category)
+			}
+			e.line("CATEGORIES", strings.Join(categories, ","))
+		}
+		e.line("TRANSP", "TRANSPARENT")
+		e.line("END", "VEVENT")
+	}
+
+	e.line("END", "VCALENDAR")
+	if e.err != nil {
+		return e.err
+	}
+	return e.w.Flush()
+}
+
+// FormatDate formats a day as an iCalendar DATE value (YYYYMMDD)
+func FormatDate(day calendar.JDN) string {
+	year, month, date := day.Gregorian()
+	return fmt.Sprintf("%04d%02d%02d", year, int(month), date)
+}
+
+// Escape escapes a TEXT value: backslash, semicolon, comma and newlines
+func Escape(text string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(text)
+}
+
+// duration formats d as a DURATION value in whole hours or days
+func duration(d time.Duration) string {
+	if d%(24*time.Hour) == 0 {
+		return fmt.Sprintf("P%dD", d/(24*time.Hour))
+	}
+	return fmt.Sprintf("PT%dH", (d+time.Hour-1)/time.Hour)
+}
+
+type encoder struct {
+	w   *bufio.Writer
+	err error
+}
+
+// line writes one content line, folding it into 75-octet chunks without
+// splitting a UTF-8 sequence
+func (e *encoder) line(name, value string) {
+	if e.err != nil {
+		return
+	}
+
+	content := name + ":" + value
+	limit := maxLineOctets
+	for len(content) > limit {
+		cut := limit
+		for cut > 0 && !utf8Start(content[cut]) {
+			cut--
+		}
+		if _, e.err = e.w.WriteString(content[:cut] + "\r\n "); e.err != nil {
+			return
+		}
+		content = content[cut:]
+		// Continuation lines start with a space that counts toward the limit
+		limit = maxLineOctets - 1
+	}
+	_, e.err = e.w.WriteString(content + "\r\n")
+}
+
+func utf8Start(b byte) bool {
+	return b&0xC0 != 0x80
+}
@@ -0,0 +1,192 @@
+package ical
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/yuxxeun/jakal/pkg/calendar"
+)
+
+func TestEscape(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"Jumat Legi", "Jumat Legi"},
+		{`C:\jakal`, `C:\\jakal`},
+		{"Weton; neptu", `Weton\; neptu`},
+		{"Sura, Sapar", `Sura\, Sapar`},
+		{"line one\nline two", `line one\nline two`},
+		{"line one\r\nline two", `line one\nline two`},
+		{`a\;b`, `a\\\;b`},
+	}
+
+	for _, tt := range tests {
+		if got := Escape(tt.text); got != tt.want {
+			t.Errorf("Escape(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func encodeLine(name, value string) string {
+	var buf bytes.Buffer
+	e := &encoder{w: bufio.NewWriter(&buf)}
+	e.line(name, value)
+	e.w.Flush()
+	return buf.String()
+}
+
+// unfold reverses the folding of RFC 5545 3.1
+func unfold(text string) string {
+	return strings.ReplaceAll(strings.TrimSuffix(text, "\r\n"), "\r\n ", "")
+}
+
+func TestLineFolding(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{"SUMMARY", "short"},
+		{"SUMMARY", strings.Repeat("a", 75-len("SUMMARY:"))},
+		{"SUMMARY", strings.Repeat("a", 76-len("SUMMARY:"))},
+		{"DESCRIPTION", strings.Repeat("Jumat Legi, neptu 11. ", 20)},
+		{"SUMMARY", strings.Repeat("Legi · 1 Sura 1959 ", 12)},
+		{"SUMMARY", strings.Repeat("·", 100)},
+	}
+
+	for _, tt := range tests {
+		got := encodeLine(tt.name, tt.value)
+		if !strings.HasSuffix(got, "\r\n") {
+			t.Errorf("%s line does not end with CRLF: %q", tt.name, got)
+		}
+
+		lines := strings.Split(strings.TrimSuffix(got, "\r\n"), "\r\n")
+		for i, line := range lines {
+			if i > 0 && !strings.HasPrefix(line, " ") {
+				t.Errorf("continuation line %d does not start with a space: %q", i, line)
+			}
+			if len(line) > maxLineOctets {
+				t.Errorf("line %d has %d octets, want at most %d: %q", i, len(line), maxLineOctets, line)
+			}
+			// Each physical line must be valid UTF-8 on its own
+			if !utf8.ValidString(line) {
+				t.Errorf("line %d splits a UTF-8 sequence: %q", i, line)
+			}
+		}
+
+		if unfolded := unfold(got); unfolded != tt.name+":"+tt.value {
+			t.Errorf("unfolded line = %q, want %q", unfolded, tt.name+":"+tt.value)
+		}
+	}
+}
+
+// The first line holds 75 octets and every continuation line 74 plus the
+// leading space
+func TestLineFoldingWidths(t *testing.T) {
+	got := encodeLine("X", strings.Repeat("a", 200))
+	lines := strings.Split(strings.TrimSuffix(got, "\r\n"), "\r\n")
+	want := []int{75, 75, 54}
+	if len(lines) != len(want) {
+		t.Fatalf("got %d lines, want %d:\n%s", len(lines), len(want), got)
+	}
+	for i, line := range lines {
+		if len(line) != want[i] {
+			t.Errorf("line %d has %d octets, want %d", i, len(line), want[i])
+		}
+	}
+}
+
+func TestDuration(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{24 * time.Hour, "P1D"},
+		{7 * 24 * time.Hour, "P7D"},
+		{2 * time.Hour, "PT2H"},
+		{90 * time.Minute, "PT2H"},
+		{36 * time.Hour, "PT36H"},
+	}
+
+	for _, tt := range tests {
+		if got := duration(tt.d); got != tt.want {
+			t.Errorf("duration(%s) = %s, want %s", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestEncode(t *testing.T) {
+	cal := &Calendar{
+		ProdID:          "-//Jakal//Test//ID",
+		Name:            "Hari Besar",
+		RefreshInterval: 24 * time.Hour,
+		Stamp:           time.Date(2025, time.June, 1, 8, 30, 0, 0, time.UTC),
+		Events: []Event{
+			{
+				UID:        "sura-1959@jakal",
+				Start:      calendar.FromGregorian(2025, time.June, 27),
+				Summary:    "1 Sura, Tahun Baru Jawa",
+				Categories: []string{"jawa", "sura"},
+				RRule:      "FREQ=DAILY;INTERVAL=35",
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := cal.Encode(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//Jakal//Test//ID",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"X-WR-CALNAME:Hari Besar",
+		"REFRESH-INTERVAL;VALUE=DURATION:P1D",
+		"X-PUBLISHED-TTL:P1D",
+		"BEGIN:VEVENT",
+		"UID:sura-1959@jakal",
+		"DTSTAMP:20250601T083000Z",
+		"DTSTART;VALUE=DATE:20250627",
+		"DTEND;VALUE=DATE:20250628",
+		"RRULE:FREQ=DAILY;INTERVAL=35",
+		`SUMMARY:1 Sura\, Tahun Baru Jawa`,
+		"CATEGORIES:jawa,sura",
+		"TRANSP:TRANSPARENT",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n") + "\r\n"
+	if got := buf.String(); got != want {
+		t.Errorf("Encode =\n%s\nwant\n%s", got, want)
+	}
+}
+
+// DTEND is the day after DTSTART, also across month, year and leap days
+func TestEventEnd(t *testing.T) {
+	tests := []struct {
+		start calendar.JDN
+		end   string
+	}{
+		{calendar.FromGregorian(2025, time.January, 31), "20250201"},
+		{calendar.FromGregorian(2024, time.February, 28), "20240229"},
+		{calendar.FromGregorian(2024, time.February, 29), "20240301"},
+		{calendar.FromGregorian(2025, time.February, 28), "20250301"},
+		{calendar.FromGregorian(2025, time.December, 31), "20260101"},
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		cal := &Calendar{Stamp: time.Now(), Events: []Event{{UID: "x", Start: tt.start}}}
+		if err := cal.Encode(&buf); err != nil {
+			t.Fatal(err)
+		}
+		if want := "DTEND;VALUE=DATE:" + tt.end + "\r\n"; !strings.Contains(buf.String(), want) {
+			t.Errorf("event on %s: missing %q in\n%s", FormatDate(tt.start), want, buf.String())
+		}
+	}
+}
@@ -0,0 +1,284 @@
+package render
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// JSON writes v with encoding/json, exactly as the API always has
+var JSON = &Format{
+	Name:        "json",
+	ContentType: "application/json",
+	Encode: func(w io.Writer, v interface{}) error {
+		return json.NewEncoder(w).Encode(v)
+	},
+}
+
+// CSV writes one row per record. The records are the first list of objects
+// found in the response "data" (for example the dates of a year), or the
+// data object itself. Nested objects become dotted columns, lists of
+// scalars are joined with "; " and lists of objects are written as JSON.
+var CSV = &Format{
+	Name:        "csv",
+	ContentType: "text/csv; charset=utf-8",
+	Encode: func(w io.Writer, v interface{}) error {
+		tree, err := Normalize(v)
+		if err != nil {
+			return err
+		}
+
+		var header []string
+		seen := make(map[string]bool)
+		var rows []map[string]string
+		for _, record := range csvRecords(tree) {
+			row := make(map[string]string)
+			flattenCSV("", record, row, func(column string) {
+				if !seen[column] {
+					seen[column] = true
+					header = append(header, column)
+				}
+			})
+			rows = append(rows, row)
+		}
+
+		writer := csv.NewWriter(w)
+		writer.Write(header)
+		for _, row := range rows {
+			line := make([]string, len(header))
+			for i, column := range header {
+				line[i] = row[column]
+			}
+			writer.Write(line)
+		}
+		writer.Flush()
+		return writer.Error()
+	},
+}
+
+func csvRecords(tree interface{}) []interface{} {
+	response, ok := tree.(Object)
+	if !ok {
+		return []interface{}{tree}
+	}
+
+	data, ok := response.Get("data")
+	if !ok || data == nil {
+		// Error responses carry no data, so their status and message are written
+		return []interface{}{response}
+	}
+
+	if list, ok := objectList(data); ok {
+		return list
+	}
+	if object, ok := data.(Object); ok {
+		for _, field := range object {
+			if list, ok := objectList(field.Value); ok {
+				return list
+			}
+		}
+	}
+	return []interface{}{data}
+}
+
+// objectList reports whether v is a non-empty list of objects
+func objectList(v interface{}) ([]interface{}, bool) {
+	list, ok := v.([]interface{})
+	if !ok || len(list) == 0 {
+		return nil, false
+	}
+	for _, item := range list {
+		if _, ok := item.(Object); !ok {
+			return nil, false
+		}
+	}
+	return list, true
+}
+
+func flattenCSV(prefix string, v interface{}, row map[string]string, addColumn func(string)) {
+	object, ok := v.(Object)
+	if !ok {
+		column := prefix
+		if column == "" {
+			column = "value"
+		}
+		addColumn(column)
+		row[column] = csvCell(v)
+		return
+	}
+
+	for _, field := range object {
+		column := field.Key
+		if prefix != "" {
+			column = prefix + "." + field.Key
+		}
+		flattenCSV(column, field.Value, row, addColumn)
+	}
+}
+
+func csvCell(v interface{}) string {
+	list, ok := v.([]interface{})
+	if !ok {
+		return scalarString(v)
+	}
+	if _, nested := objectList(list); nested {
+		data, _ := json.Marshal(toPlain(list))
+		return string(data)
+	}
+	cells := make([]string, len(list))
+	for i, item := range list {
+		cells[i] = scalarString(item)
+	}
+	return strings.Join(cells, "; ")
+}
+
+// XML writes the response under a <response> root. List items become
+// <item> elements; object keys that are not valid XML names (such as
+// "Selasa Legi" in statistics) become <entry key="...">.
+var XML = &Format{
+	Name:        "xml",
+	ContentType: "application/xml; charset=utf-8",
+	MediaTypes:  []string{"text/xml"},
+	Encode: func(w io.Writer, v interface{}) error {
+		tree, err := Normalize(v)
+		if err != nil {
+			return err
+		}
+
+		var b strings.Builder
+		b.WriteString(xml.Header)
+		writeXML(&b, "response", "", tree)
+		b.WriteString("\n")
+		_, err = io.WriteString(w, b.String())
+		return err
+	},
+}
+
+func writeXML(b *strings.Builder, name, key string, v interface{}) {
+	open := name
+	if key != "" {
+		var escaped strings.Builder
+		xml.EscapeText(&escaped, []byte(key))
+		open = fmt.Sprintf(`%s key="%s"`, name, escaped.String())
+	}
+
+	switch value := v.(type) {
+	case nil:
+		b.WriteString("<" + open + "/>")
+	case Object:
+		b.WriteString("<" + open + ">")
+		for _, field := range value {
+			if validXMLName(field.Key) {
+				writeXML(b, field.Key, "", field.Value)
+			} else {
+				writeXML(b, "entry", field.Key, field.Value)
+			}
+		}
+		b.WriteString("</" + name + ">")
+	case []interface{}:
+		b.WriteString("<" + open + ">")
+		for _, item := range value {
+			writeXML(b, "item", "", item)
+		}
+		b.WriteString("</" + name + ">")
+	default:
+		b.WriteString("<" + open + ">")
+		xml.EscapeText(b, []byte(scalarString(value)))
+		b.WriteString("</" + name + ">")
+	}
+}
+
+func validXMLName(name string) bool {
+	if name == "" || strings.HasPrefix(strings.ToLower(name), "xml") {
+		return false
+	}
+	for i, r := range name {
+		letter := r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+		if i == 0 && !letter {
+			return false
+		}
+		if !letter && r != '-' && r != '.' && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// MessagePack writes the JSON tree as MessagePack, using the smallest
+// encoding for each integer and float64 for other numbers
+var MessagePack = &Format{
+	Name:        "msgpack",
+	ContentType: "application/msgpack",
+	MediaTypes:  []string{"application/x-msgpack", "application/vnd.msgpack"},
+	Encode: func(w io.Writer, v interface{}) error {
+		tree, err := Normalize(v)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(appendMsgpack(nil, tree))
+		return err
+	},
+}
+
+// Text writes the response as indented "key: value" lines for terminals
+var Text = &Format{
+	Name:        "text",
+	ContentType: "text/plain; charset=utf-8",
+	Encode: func(w io.Writer, v interface{}) error {
+		tree, err := Normalize(v)
+		if err != nil {
+			return err
+		}
+
+		var b strings.Builder
+		writeText(&b, 0, tree)
+		_, err = io.WriteString(w, b.String())
+		return err
+	},
+}
+
+func writeText(b *strings.Builder, indent int, v interface{}) {
+	pad := strings.Repeat("  ", indent)
+
+	switch value := v.(type) {
+	case Object:
+		for _, field := range value {
+			switch field.Value.(type) {
+			case Object, []interface{}:
+				if empty(field.Value) {
+					fmt.Fprintf(b, "%s%s: -\n", pad, field.Key)
+					continue
+				}
+				fmt.Fprintf(b, "%s%s:\n", pad, field.Key)
+				writeText(b, indent+1, field.Value)
+			default:
+				fmt.Fprintf(b, "%s%s: %s\n", pad, field.Key, scalarString(field.Value))
+			}
+		}
+	case []interface{}:
+		for i, item := range value {
+			switch item.(type) {
+			case Object, []interface{}:
+				fmt.Fprintf(b, "%s- [%d]\n", pad, i+1)
+				writeText(b, indent+1, item)
+			default:
+				fmt.Fprintf(b, "%s- %s\n", pad, scalarString(item))
+			}
+		}
+	default:
+		fmt.Fprintf(b, "%s%s\n", pad, scalarString(value))
+	}
+}
+
+func empty(v interface{}) bool {
+	switch value := v.(type) {
+	case Object:
+		return len(value) == 0
+	case []interface{}:
+		return len(value) == 0
+	}
+	return false
+}
@@ -0,0 +1,186 @@
+package render
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/yuxxeun/jakal/internal/model"
+)
+
+type sampleHijri struct {
+	Day   int    `json:"day"`
+	Month string `json:"month"`
+}
+
+type sampleDate struct {
+	Date    string       `json:"date"`
+	Weton   string       `json:"weton"`
+	Neptu   int          `json:"neptu"`
+	Holiday bool         `json:"holiday"`
+	Tags    []string     `json:"tags"`
+	Hijri   *sampleHijri `json:"hijri"`
+}
+
+type sampleData struct {
+	Year  int            `json:"year"`
+	Dates []sampleDate   `json:"dates"`
+	Count map[string]int `json:"count"`
+}
+
+var sampleResponse = model.APIResponse{
+	Status:  "success",
+	Message: "Weton <Juni> & Juli",
+	Data: sampleData{
+		Year: 2025,
+		Dates: []sampleDate{
+			{"2025-06-27", "Jumat Legi", 11, true, []string{"1 Sura", "Tahun Baru Islam"}, &sampleHijri{1, "Muharram"}},
+			{"2025-06-28", "Sabtu Pahing", 18, false, nil, nil},
+		},
+		Count: map[string]int{"Jumat Legi": 1, "Sabtu Pahing": 1},
+	},
+}
+
+func encode(t *testing.T, format *Format, v interface{}) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := format.Encode(&buf, v); err != nil {
+		t.Fatalf("%s: %v", format.Name, err)
+	}
+	return buf.String()
+}
+
+func TestJSON(t *testing.T) {
+	// encoding/json escapes HTML characters, as the API always has
+	want := `{"status":"success","message":"Weton \u003cJuni\u003e \u0026 Juli","data":{"year":2025,` +
+		`"dates":[{"date":"2025-06-27","weton":"Jumat Legi","neptu":11,"holiday":true,"tags":["1 Sura","Tahun Baru Islam"],"hijri":{"day":1,"month":"Muharram"}},` +
+		`{"date":"2025-06-28","weton":"Sabtu Pahing","neptu":18,"holiday":false,"tags":null,"hijri":null}],` +
+		`"count":{"Jumat Legi":1,"Sabtu Pahing":1}}}` + "\n"
+	if got := encode(t, JSON, sampleResponse); got != want {
+		t.Errorf("JSON =\n%s\nwant\n%s", got, want)
+	}
+}
+
+// The records are the first list of objects in data; the other data fields
+// are not columns
+func TestCSV(t *testing.T) {
+	want := "date,weton,neptu,holiday,tags,hijri.day,hijri.month,hijri\n" +
+		"2025-06-27,Jumat Legi,11,true,1 Sura; Tahun Baru Islam,1,Muharram,\n" +
+		"2025-06-28,Sabtu Pahing,18,false,,,,\n"
+	if got := encode(t, CSV, sampleResponse); got != want {
+		t.Errorf("CSV =\n%s\nwant\n%s", got, want)
+	}
+
+	errorResponse := model.APIResponse{Status: "error", Message: "Tahun harus antara 1 - 9999"}
+	want = "status,message,data\nerror,Tahun harus antara 1 - 9999,\n"
+	if got := encode(t, CSV, errorResponse); got != want {
+		t.Errorf("CSV error =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestXML(t *testing.T) {
+	want := `<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+		`<response><status>success</status><message>Weton &lt;Juni&gt; &amp; Juli</message><data><year>2025</year><dates>` +
+		`<item><date>2025-06-27</date><weton>Jumat Legi</weton><neptu>11</neptu><holiday>true</holiday>` +
+		`<tags><item>1 Sura</item><item>Tahun Baru Islam</item></tags><hijri><day>1</day><month>Muharram</month></hijri></item>` +
+		`<item><date>2025-06-28</date><weton>Sabtu Pahing</weton><neptu>18</neptu><holiday>false</holiday><tags/><hijri/></item>` +
+		`</dates><count><entry key="Jumat Legi">1</entry><entry key="Sabtu Pahing">1</entry></count></data></response>` + "\n"
+	if got := encode(t, XML, sampleResponse); got != want {
+		t.Errorf("XML =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestText(t *testing.T) {
+	// null values keep their key with an empty value
+	want := strings.Join([]string{
+		"status: success",
+		"message: Weton <Juni> & Juli",
+		"data:",
+		"  year: 2025",
+		"  dates:",
+		"    - [1]",
+		"      date: 2025-06-27",
+		"      weton: Jumat Legi",
+		"      neptu: 11",
+		"      holiday: true",
+		"      tags:",
+		"        - 1 Sura",
+		"        - Tahun Baru Islam",
+		"      hijri:",
+		"        day: 1",
+		"        month: Muharram",
+		"    - [2]",
+		"      date: 2025-06-28",
+		"      weton: Sabtu Pahing",
+		"      neptu: 18",
+		"      holiday: false",
+		"      tags: ",
+		"      hijri: ",
+		"  count:",
+		"    Jumat Legi: 1",
+		"    Sabtu Pahing: 1",
+	}, "\n") + "\n"
+	if got := encode(t, Text, sampleResponse); got != want {
+		t.Errorf("Text =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestMessagePack(t *testing.T) {
+	response := model.APIResponse{
+		Status:  "success",
+		Message: "",
+		Data: map[string]interface{}{
+			"ints":  []int{0, 127, 128, -1, -33, 70000},
+			"float": 0.5,
+			"ok":    true,
+			"none":  nil,
+		},
+	}
+	// Map keys are sorted the way encoding/json sorts them
+	want := "83" +
+		"a6737461747573" + "a773756363657373" + // status: success
+		"a76d657373616765" + "a0" + // message: ""
+		"a464617461" + "84" + // data: 4 entries
+		"a5666c6f6174" + "cb3fe0000000000000" + // float: 0.5
+		"a4696e7473" + "96" + "00" + "7f" + "cc80" + "ff" + "d0df" + "ce00011170" + // ints
+		"a46e6f6e65" + "c0" + // none: nil
+		"a26f6b" + "c3" // ok: true
+
+	got := hex.EncodeToString([]byte(encode(t, MessagePack, response)))
+	if got != want {
+		t.Errorf("MessagePack =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestMessagePackLengths(t *testing.T) {
+	long := make([]int, 16)
+	got := []byte(encode(t, MessagePack, long))
+	if got[0] != 0xdc || got[1] != 0 || got[2] != 16 || len(got) != 3+16 {
+		t.Errorf("16-item array header = % x", got[:3])
+	}
+
+	text := string(bytes.Repeat([]byte("a"), 32))
+	got = []byte(encode(t, MessagePack, text))
+	if got[0] != 0xd9 || got[1] != 32 {
+		t.Errorf("32-byte string header = % x", got[:2])
+	}
+}
+
+// Every format must describe the same tree, so the XML and CSV encoders
+// see the fields in JSON order
+func TestNormalizeKeepsJSONOrder(t *testing.T) {
+	tree, err := Normalize(sampleResponse)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plain, err := json.Marshal(toPlain(tree))
+	if err != nil {
+		t.Fatal(err)
+	}
+	direct, _ := json.Marshal(sampleResponse)
+	if !bytes.Equal(plain, direct) {
+		t.Errorf("Normalize round trip =\n%s\nwant\n%s", plain, direct)
+	}
+}
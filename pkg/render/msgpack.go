@@ -0,0 +1,93 @@
+package render
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"math"
+)
+
+// appendMsgpack appends the MessagePack encoding of a Normalize tree
+func appendMsgpack(b []byte, v interface{}) []byte {
+	switch value := v.(type) {
+	case nil:
+		return append(b, 0xc0)
+	case bool:
+		if value {
+			return append(b, 0xc3)
+		}
+		return append(b, 0xc2)
+	case string:
+		return appendMsgpackString(b, value)
+	case json.Number:
+		if n, err := value.Int64(); err == nil {
+			return appendMsgpackInt(b, n)
+		}
+		f, _ := value.Float64()
+		b = append(b, 0xcb)
+		return binary.BigEndian.AppendUint64(b, math.Float64bits(f))
+	case []interface{}:
+		b = appendMsgpackHeader(b, len(value), 0x90, 0xdc, 0xdd)
+		for _, item := range value {
+			b = appendMsgpack(b, item)
+		}
+		return b
+	case Object:
+		b = appendMsgpackHeader(b, len(value), 0x80, 0xde, 0xdf)
+		for _, field := range value {
+			b = appendMsgpackString(b, field.Key)
+			b = appendMsgpack(b, field.Value)
+		}
+		return b
+	}
+	return append(b, 0xc0)
+}
+
+// appendMsgpackHeader writes a fix, 16-bit or 32-bit array or map length
+func appendMsgpackHeader(b []byte, n int, fix, code16, code32 byte) []byte {
+	switch {
+	case n < 16:
+		return append(b, fix|byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, code16), uint16(n))
+	}
+	return binary.BigEndian.AppendUint32(append(b, code32), uint32(n))
+}
+
+func appendMsgpackString(b []byte, s string) []byte {
+	n := len(s)
+	switch {
+	case n < 32:
+		b = append(b, 0xa0|byte(n))
+	case n <= math.MaxUint8:
+		b = append(b, 0xd9, byte(n))
+	case n <= math.MaxUint16:
+		b = binary.BigEndian.AppendUint16(append(b, 0xda), uint16(n))
+	default:
+		b = binary.BigEndian.AppendUint32(append(b, 0xdb), uint32(n))
+	}
+	return append(b, s...)
+}
+
+func appendMsgpackInt(b []byte, n int64) []byte {
+	switch {
+	case n >= 0 && n <= 127:
+		return append(b, byte(n))
+	case n < 0 && n >= -32:
+		return append(b, byte(n))
+	case n >= 0 && n <= math.MaxUint8:
+		return append(b, 0xcc, byte(n))
+	case n >= 0 && n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, 0xcd), uint16(n))
+	case n >= 0 && n <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(b, 0xce), uint32(n))
+	case n >= math.MinInt8 && n < 0:
+		return append(b, 0xd0, byte(n))
+	case n >= math.MinInt16 && n < 0:
+		return binary.BigEndian.AppendUint16(append(b, 0xd1), uint16(n))
+	case n >= math.MinInt32 && n < 0:
+		return binary.BigEndian.AppendUint32(append(b, 0xd2), uint32(n))
+	case n < 0:
+		return binary.BigEndian.AppendUint64(append(b, 0xd3), uint64(n))
+	}
+	return binary.BigEndian.AppendUint64(append(b, 0xcf), uint64(n))
+}
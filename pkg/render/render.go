@@ -0,0 +1,149 @@
+// Package render encodes API responses in the format a client negotiates.
+// Formats live in a Registry; adding a format is a single Register call and
+// needs no change in the handlers that produce the data.
+package render
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Format is one output format
+type Format struct {
+	// Name is the value accepted by ?format=
+	Name string
+	// ContentType is sent with every response in this format
+	ContentType string
+	// MediaTypes lists additional Accept media types that select the format
+	MediaTypes []string
+	// Encode writes v to w. Returning an error before writing anything lets
+	// the caller fall back to another format.
+	Encode func(w io.Writer, v interface{}) error
+}
+
+func (f *Format) matches(mediaType string) bool {
+	if base, _, err := mime.ParseMediaType(f.ContentType); err == nil && base == mediaType {
+		return true
+	}
+	for _, candidate := range f.MediaTypes {
+		if candidate == mediaType {
+			return true
+		}
+	}
+	return false
+}
+
+// Registry holds the available formats. The first registered format is
+// the default when the client expresses no usable preference.
+type Registry struct {
+	formats []*Format
+}
+
+// NewRegistry returns an empty registry
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register adds a format, replacing any format with the same name
+func (r *Registry) Register(format *Format) {
+	for i, existing := range r.formats {
+		if existing.Name == format.Name {
+			r.formats[i] = format
+			return
+		}
+	}
+	r.formats = append(r.formats, format)
+}
+
+// Default returns the first registered format
+func (r *Registry) Default() *Format {
+	if len(r.formats) == 0 {
+		return nil
+	}
+	return r.formats[0]
+}
+
+// Lookup returns the format registered under name (case-insensitive)
+func (r *Registry) Lookup(name string) (*Format, bool) {
+	for _, format := range r.formats {
+		if strings.EqualFold(format.Name, name) {
+			return format, true
+		}
+	}
+	return nil, false
+}
+
+// Names lists the registered format names in registration order
+func (r *Registry) Names() []string {
+	names := make([]string, len(r.formats))
+	for i, format := range r.formats {
+		names[i] = format.Name
+	}
+	return names
+}
+
+// Negotiate picks a format from an explicit ?format= value, falling back to
+// the Accept header. An unknown explicit format is an error; an Accept header
+// without any supported type selects the default format.
+func (r *Registry) Negotiate(explicit, accept string) (*Format, error) {
+	if explicit != "" {
+		format, ok := r.Lookup(explicit)
+		if !ok {
+			return nil, fmt.Errorf("unknown format %q", explicit)
+		}
+		return format, nil
+	}
+
+	for _, mediaType := range parseAccept(accept) {
+		if mediaType == "*/*" || mediaType == "application/*" {
+			return r.Default(), nil
+		}
+		for _, format := range r.formats {
+			if format.matches(mediaType) {
+				return format, nil
+			}
+		}
+	}
+	return r.Default(), nil
+}
+
+// parseAccept returns the acceptable media types ordered by quality,
+// keeping the header order between equal qualities and dropping q=0
+func parseAccept(accept string) []string {
+	type weighted struct {
+		mediaType string
+		quality   float64
+	}
+
+	var entries []weighted
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		quality := 1.0
+		if q, ok := params["q"]; ok {
+			if parsed, err := strconv.ParseFloat(q, 64); err == nil {
+				quality = parsed
+			}
+		}
+		if quality <= 0 {
+			continue
+		}
+		entries = append(entries, weighted{mediaType, quality})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].quality > entries[j].quality
+	})
+
+	mediaTypes := make([]string, len(entries))
+	for i, entry := range entries {
+		mediaTypes[i] = entry.mediaType
+	}
+	return mediaTypes
+}
@@ -0,0 +1,106 @@
+package render
+
+import (
+	"reflect"
+	"testing"
+)
+
+func testRegistry() *Registry {
+	registry := NewRegistry()
+	registry.Register(JSON)
+	registry.Register(CSV)
+	registry.Register(XML)
+	registry.Register(MessagePack)
+	registry.Register(Text)
+	return registry
+}
+
+func TestParseAccept(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   []string
+	}{
+		{"", []string{}},
+		{"text/csv", []string{"text/csv"}},
+		{"text/csv;q=0.5, application/xml", []string{"application/xml", "text/csv"}},
+		{"text/plain; q=0.8, text/csv; q=0.8, application/json; q=0.9", []string{"application/json", "text/plain", "text/csv"}},
+		{"application/json;q=0, text/csv", []string{"text/csv"}},
+		{"text/csv;q=abc", []string{"text/csv"}},
+		{"not a media type, text/csv", []string{"text/csv"}},
+	}
+
+	for _, tt := range tests {
+		if got := parseAccept(tt.accept); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseAccept(%q) = %q, want %q", tt.accept, got, tt.want)
+		}
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	registry := testRegistry()
+	tests := []struct {
+		explicit string
+		accept   string
+		want     string
+	}{
+		{"", "", "json"},
+		{"", "*/*", "json"},
+		{"", "text/csv", "csv"},
+		{"", "text/xml", "xml"},
+		{"", "application/x-msgpack", "msgpack"},
+		{"", "image/png", "json"},
+		{"", "image/png, text/plain", "text"},
+		{"", "text/csv;q=0.5, application/xml;q=0.9", "xml"},
+		{"", "text/csv;q=0.5, */*;q=0.9", "json"},
+		{"", "application/json;q=0, text/plain;q=0.1", "text"},
+		{"", "text/html, application/*;q=0.8, text/csv;q=0.2", "json"},
+		{"csv", "application/xml", "csv"},
+		{"XML", "", "xml"},
+	}
+
+	for _, tt := range tests {
+		format, err := registry.Negotiate(tt.explicit, tt.accept)
+		if err != nil {
+			t.Errorf("Negotiate(%q, %q): %v", tt.explicit, tt.accept, err)
+			continue
+		}
+		if format.Name != tt.want {
+			t.Errorf("Negotiate(%q, %q) = %s, want %s", tt.explicit, tt.accept, format.Name, tt.want)
+		}
+	}
+}
+
+// An unregistered ?format= is an error even when Accept names a known type
+func TestNegotiateUnknownFormat(t *testing.T) {
+	registry := testRegistry()
+	for _, explicit := range []string{"yaml", "ics", "json "} {
+		if format, err := registry.Negotiate(explicit, "application/json"); err == nil {
+			t.Errorf("Negotiate(%q) = %s, want an error", explicit, format.Name)
+		}
+	}
+}
+
+func TestRegister(t *testing.T) {
+	registry := NewRegistry()
+	if registry.Default() != nil {
+		t.Error("empty registry has a default format")
+	}
+	if format, err := registry.Negotiate("", "text/csv"); err != nil || format != nil {
+		t.Errorf("empty registry negotiated %v, %v", format, err)
+	}
+
+	registry.Register(JSON)
+	registry.Register(Text)
+	replacement := &Format{Name: "text", ContentType: "text/plain; charset=utf-8"}
+	registry.Register(replacement)
+
+	if got := registry.Names(); !reflect.DeepEqual(got, []string{"json", "text"}) {
+		t.Errorf("Names() = %q, want [json text]", got)
+	}
+	if format, ok := registry.Lookup("Text"); !ok || format != replacement {
+		t.Errorf("Lookup(Text) = %v, %v; want the replacement", format, ok)
+	}
+	if registry.Default() != JSON {
+		t.Errorf("Default() = %s, want json", registry.Default().Name)
+	}
+}
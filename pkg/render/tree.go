@@ -0,0 +1,135 @@
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Object is a JSON object with its keys in encoding order, so every format
+// lists fields in the same order as the JSON output
+type Object []Field
+
+// Field is one key/value pair of an Object
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// Get returns the value stored under key
+func (o Object) Get(key string) (interface{}, bool) {
+	for _, field := range o {
+		if field.Key == key {
+			return field.Value, true
+		}
+	}
+	return nil, false
+}
+
+// Normalize turns v into the tree its JSON encoding describes: Object,
+// []interface{}, string, json.Number, bool or nil. Going through JSON keeps
+// struct tags and omitempty rules identical across formats.
+func Normalize(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decodeValue(decoder)
+}
+
+func decodeValue(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	delim, ok := token.(json.Delim)
+	if !ok {
+		return token, nil
+	}
+
+	switch delim {
+	case '{':
+		object := Object{}
+		for decoder.More() {
+			keyToken, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			key, ok := keyToken.(string)
+			if !ok {
+				return nil, fmt.Errorf("render: object key %v is not a string", keyToken)
+			}
+			value, err := decodeValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+			object = append(object, Field{Key: key, Value: value})
+		}
+		_, err := decoder.Token()
+		return object, err
+	case '[':
+		array := []interface{}{}
+		for decoder.More() {
+			value, err := decodeValue(decoder)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, value)
+		}
+		_, err := decoder.Token()
+		return array, err
+	}
+	return nil, fmt.Errorf("render: unexpected delimiter %v", delim)
+}
+
+// scalarString formats a scalar tree value as plain text
+func scalarString(v interface{}) string {
+	switch value := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case json.Number:
+		return value.String()
+	case bool:
+		if value {
+			return "true"
+		}
+		return "false"
+	}
+	data, _ := json.Marshal(v)
+	return string(data)
+}
+
+// toPlain converts an Object tree back to plain maps and slices so it can be
+// handed to encoding/json
+func toPlain(v interface{}) interface{} {
+	switch value := v.(type) {
+	case Object:
+		var buf bytes.Buffer
+		buf.WriteByte('{')
+		for i, field := range value {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			key, _ := json.Marshal(field.Key)
+			buf.Write(key)
+			buf.WriteByte(':')
+			item, _ := json.Marshal(toPlain(field.Value))
+			buf.Write(item)
+		}
+		buf.WriteByte('}')
+		return json.RawMessage(buf.Bytes())
+	case []interface{}:
+		items := make([]interface{}, len(value))
+		for i, item := range value {
+			items[i] = toPlain(item)
+		}
+		return items
+	}
+	return v
+}